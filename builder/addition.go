@@ -17,6 +17,7 @@ const (
 	AdditionJoin AdditionType = iota
 	AdditionWhere
 	AdditionGroupBy
+	AdditionWindow
 	AdditionCombination
 	AdditionOrderBy
 	AdditionLimit
@@ -66,6 +67,8 @@ func AsAddition(expr SqlExpr) *OtherAddition {
 
 type OtherAddition struct {
 	SqlExpr
+	// locking read like FOR UPDATE, which makes the statement primary only
+	locking bool
 }
 
 func (OtherAddition) AdditionType() AdditionType {
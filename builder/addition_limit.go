@@ -33,10 +33,14 @@ func (l limit) Offset(offset int64) *limit {
 }
 
 func (l *limit) IsNil() bool {
-	return l == nil || l.rowCount <= 0
+	return l == nil || (l.rowCount <= 0 && l.offsetCount <= 0)
 }
 
 func (l *limit) Ex(ctx context.Context) *Ex {
+	if l.rowCount <= 0 {
+		return l.offsetOnly(ctx)
+	}
+
 	e := ExactlyExpr("LIMIT ")
 
 	e.WriteQuery(strconv.FormatInt(l.rowCount, 10))
@@ -48,3 +52,21 @@ func (l *limit) Ex(ctx context.Context) *Ex {
 
 	return e.Ex(ctx)
 }
+
+// offsetOnly renders offset without row count, which mysql and sqlite require a LIMIT for
+func (l *limit) offsetOnly(ctx context.Context) *Ex {
+	e := ExactlyExpr("")
+
+	switch driverNameFromContext(ctx) {
+	case "postgres":
+	case "mysql":
+		e.WriteQuery("LIMIT 18446744073709551615 ")
+	default:
+		e.WriteQuery("LIMIT -1 ")
+	}
+
+	e.WriteQuery("OFFSET ")
+	e.WriteQuery(strconv.FormatInt(l.offsetCount, 10))
+
+	return e.Ex(ctx)
+}
@@ -1,6 +1,7 @@
 package builder_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/kunlun-qilian/sqlx/v3/builder"
@@ -61,4 +62,26 @@ LIMIT 1 OFFSET 200
 			1,
 		))
 	})
+	t.Run("select offset only", func(t *testing.T) {
+		exprFor := func(driverName string, expr SqlExpr) SqlExpr {
+			return ExprBy(func(ctx context.Context) *Ex {
+				return expr.Ex(ContextWithDialect(ctx, &dialectWithDriverName{driverName: driverName}))
+			})
+		}
+
+		stmt := Select(nil).From(table, Limit(-1).Offset(200))
+
+		gomega.NewWithT(t).Expect(exprFor("postgres", stmt)).To(BeExpr(`
+SELECT * FROM T
+OFFSET 200
+`))
+		gomega.NewWithT(t).Expect(exprFor("mysql", stmt)).To(BeExpr(`
+SELECT * FROM T
+LIMIT 18446744073709551615 OFFSET 200
+`))
+		gomega.NewWithT(t).Expect(exprFor("sqlite", stmt)).To(BeExpr(`
+SELECT * FROM T
+LIMIT -1 OFFSET 200
+`))
+	})
 }
@@ -82,3 +82,82 @@ func (o *Order) Ex(ctx context.Context) *Ex {
 
 	return e.Ex(ctx)
 }
+
+// Target returns the expr to order by
+func (o *Order) Target() SqlExpr {
+	return o.target
+}
+
+// IsDesc returns whether order by DESC
+func (o *Order) IsDesc() bool {
+	return o.typ == "DESC"
+}
+
+// KeysetCond returns condition to select rows after values of orders for keyset pagination,
+// as (a, b) > (?, ?) when orders in same direction,
+// otherwise expanded as a > ? OR (a = ? AND b < ?).
+func KeysetCond(orders []*Order, values []interface{}) SqlCondition {
+	if len(orders) == 0 || len(orders) != len(values) {
+		return nil
+	}
+
+	sameDirection := true
+	for i := range orders {
+		if orders[i].IsDesc() != orders[0].IsDesc() {
+			sameDirection = false
+			break
+		}
+	}
+
+	if sameDirection {
+		e := Expr("")
+		e.Grow(len(orders) * 2)
+
+		e.WriteGroup(func(e *Ex) {
+			for i := range orders {
+				if i > 0 {
+					e.WriteQuery(", ")
+				}
+				e.WriteExpr(orders[i].target)
+			}
+		})
+
+		e.WriteQuery(keysetOperator(orders[0]))
+
+		e.WriteGroup(func(e *Ex) {
+			for i := range values {
+				if i > 0 {
+					e.WriteQuery(", ")
+				}
+				e.WriteQueryByte('?')
+			}
+		})
+		e.AppendArgs(values...)
+
+		return AsCond(e)
+	}
+
+	conditions := make([]SqlCondition, 0, len(orders))
+
+	for i := range orders {
+		parts := make([]SqlCondition, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, AsCond(Expr("? = ?", orders[j].target, values[j])))
+		}
+		parts = append(parts, AsCond(Expr("?"+keysetOperator(orders[i])+"?", orders[i].target, values[i])))
+		if len(parts) == 1 {
+			conditions = append(conditions, parts[0])
+			continue
+		}
+		conditions = append(conditions, And(parts...))
+	}
+
+	return Or(conditions...)
+}
+
+func keysetOperator(o *Order) string {
+	if o.IsDesc() {
+		return " < "
+	}
+	return " > "
+}
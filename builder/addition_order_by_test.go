@@ -32,3 +32,59 @@ ORDER BY (f_a) ASC,(f_b) DESC
 		))
 	})
 }
+
+func TestKeysetCond(t *testing.T) {
+	table := T("T")
+
+	t.Run("same direction", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(nil).
+				From(
+					table,
+					Where(KeysetCond(
+						[]*Order{AscOrder(Col("F_a")), AscOrder(Col("F_b"))},
+						[]interface{}{1, 2},
+					)),
+				),
+		).To(BeExpr(`
+SELECT * FROM T
+WHERE (f_a, f_b) > (?, ?)
+`, 1, 2))
+	})
+
+	t.Run("desc", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(nil).
+				From(
+					table,
+					Where(KeysetCond(
+						[]*Order{DescOrder(Col("F_a"))},
+						[]interface{}{1},
+					)),
+				),
+		).To(BeExpr(`
+SELECT * FROM T
+WHERE (f_a) < (?)
+`, 1))
+	})
+
+	t.Run("mixed direction", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(nil).
+				From(
+					table,
+					Where(KeysetCond(
+						[]*Order{DescOrder(Col("F_a")), AscOrder(Col("F_b"))},
+						[]interface{}{1, 2},
+					)),
+				),
+		).To(BeExpr(`
+SELECT * FROM T
+WHERE (f_a < ?) OR ((f_a = ?) AND (f_b > ?))
+`, 1, 1, 2))
+	})
+
+	t.Run("values not match", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(KeysetCond([]*Order{AscOrder(Col("F_a"))}, nil)).To(gomega.BeNil())
+	})
+}
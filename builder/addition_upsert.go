@@ -0,0 +1,159 @@
+package builder
+
+import (
+	"context"
+)
+
+// Upsert dialect-neutral upsert, rendered by the dialect in context when executing.
+//
+// keyOrColumns should be *Key or *Columns as conflict target:
+// mysql: ON DUPLICATE KEY UPDATE f_a = VALUES(f_a)
+// others: ON CONFLICT (f_key) DO UPDATE SET f_a = EXCLUDED.f_a
+func Upsert(keyOrColumns interface{}) *upsert {
+	u := &upsert{}
+
+	switch v := keyOrColumns.(type) {
+	case *Columns:
+		if !IsNilExpr(v) {
+			u.columns = v
+			u.target = ExprBy(func(ctx context.Context) *Ex {
+				e := Expr("")
+				e.WriteGroup(func(e *Ex) {
+					e.WriteExpr(v)
+				})
+				return e.Ex(ctx)
+			})
+		}
+	case *Key:
+		if v != nil && v.Table != nil {
+			if len(v.Def.ColNames) != 0 {
+				u.columns = v.Table.MustCols(v.Def.ColNames...)
+			} else if len(v.Def.FieldNames) != 0 {
+				u.columns = v.Table.MustFields(v.Def.FieldNames...)
+			}
+			u.target = v.Def.TableExpr(v.Table)
+		}
+	}
+
+	return u
+}
+
+var _ Addition = (*upsert)(nil)
+
+type upsert struct {
+	OnConflictAddition
+
+	target      SqlExpr
+	columns     *Columns
+	doNothing   bool
+	assignments []*Assignment
+	excluded    []*Column
+}
+
+func (u upsert) DoNothing() *upsert {
+	u.doNothing = true
+	return &u
+}
+
+func (u upsert) DoUpdate(assignments ...*Assignment) *upsert {
+	u.assignments = append(append(make([]*Assignment, 0, len(u.assignments)+len(assignments)), u.assignments...), assignments...)
+	return &u
+}
+
+// UpdateExcluded update columns by the values which are trying to insert
+func (u upsert) UpdateExcluded(cols ...*Column) *upsert {
+	u.excluded = append(append(make([]*Column, 0, len(u.excluded)+len(cols)), u.excluded...), cols...)
+	return &u
+}
+
+func (u *upsert) IsNil() bool {
+	return u == nil || IsNilExpr(u.target) || (!u.doNothing && len(u.assignments) == 0 && len(u.excluded) == 0)
+}
+
+func (u *upsert) Ex(ctx context.Context) *Ex {
+	if driverNameFromContext(ctx) == "mysql" {
+		return u.onDuplicateKeyUpdate(ctx)
+	}
+	return u.onConflict(ctx)
+}
+
+func (u *upsert) onDuplicateKeyUpdate(ctx context.Context) *Ex {
+	e := Expr("ON DUPLICATE KEY UPDATE ")
+
+	if u.doNothing {
+		// no-op update to ignore conflict
+		if col := u.firstColumn(); col != nil {
+			e.WriteQuery(col.Name)
+			e.WriteQuery(" = ")
+			e.WriteQuery(col.Name)
+		}
+		return e.Ex(ctx)
+	}
+
+	u.writeAssignments(e, func(col *Column) {
+		e.WriteQuery("VALUES(")
+		e.WriteQuery(col.Name)
+		e.WriteQueryByte(')')
+	})
+
+	return e.Ex(ctx)
+}
+
+func (u *upsert) onConflict(ctx context.Context) *Ex {
+	e := Expr("ON CONFLICT ")
+
+	e.WriteExpr(u.target)
+
+	e.WriteQuery(" DO ")
+
+	if u.doNothing {
+		e.WriteQuery("NOTHING")
+		return e.Ex(ctx)
+	}
+
+	e.WriteQuery("UPDATE SET ")
+
+	u.writeAssignments(e, func(col *Column) {
+		e.WriteQuery("EXCLUDED.")
+		e.WriteQuery(col.Name)
+	})
+
+	return e.Ex(ctx)
+}
+
+func (u *upsert) writeAssignments(e *Ex, writeExcluded func(col *Column)) {
+	n := 0
+
+	for i := range u.assignments {
+		if IsNilExpr(u.assignments[i]) {
+			continue
+		}
+		if n > 0 {
+			e.WriteQuery(", ")
+		}
+		e.WriteExpr(u.assignments[i])
+		n++
+	}
+
+	for i := range u.excluded {
+		if u.excluded[i] == nil {
+			continue
+		}
+		if n > 0 {
+			e.WriteQuery(", ")
+		}
+		e.WriteQuery(u.excluded[i].Name)
+		e.WriteQuery(" = ")
+		writeExcluded(u.excluded[i])
+		n++
+	}
+}
+
+func (u *upsert) firstColumn() *Column {
+	if u.columns != nil {
+		if list := u.columns.List(); len(list) > 0 {
+			return list[0]
+		}
+	}
+	return nil
+}
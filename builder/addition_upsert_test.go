@@ -0,0 +1,88 @@
+package builder_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/kunlun-qilian/sqlx/v3/builder/buidertestingutils"
+	"github.com/onsi/gomega"
+)
+
+type dialectWithDriverName struct {
+	Dialect
+	driverName string
+}
+
+func (d *dialectWithDriverName) DriverName() string {
+	return d.driverName
+}
+
+func TestUpsert(t *testing.T) {
+	table := T("T",
+		Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+		Col("f_a").Field("A").Type("", ""),
+		Col("f_b").Field("B").Type(0, ""),
+		UniqueIndex("i_a", Cols("f_a")),
+	)
+
+	exprFor := func(driverName string, expr SqlExpr) SqlExpr {
+		return ExprBy(func(ctx context.Context) *Ex {
+			return expr.Ex(ContextWithDialect(ctx, &dialectWithDriverName{driverName: driverName}))
+		})
+	}
+
+	insert := func(additions ...Addition) SqlExpr {
+		return Insert().Into(table, additions...).Values(table.MustCols("f_a", "f_b"), "a", 1)
+	}
+
+	t.Run("update", func(t *testing.T) {
+		addition := Upsert(table.Key("i_a")).
+			DoUpdate(table.Col("f_b").ValueBy(2)).
+			UpdateExcluded(table.Col("f_a"))
+
+		gomega.NewWithT(t).Expect(exprFor("mysql", insert(addition))).To(BeExpr(`
+INSERT INTO T (f_a,f_b) VALUES (?,?)
+ON DUPLICATE KEY UPDATE f_b = ?, f_a = VALUES(f_a)
+`, "a", 1, 2))
+
+		gomega.NewWithT(t).Expect(exprFor("postgres", insert(addition))).To(BeExpr(`
+INSERT INTO T (f_a,f_b) VALUES (?,?)
+ON CONFLICT (f_a) DO UPDATE SET f_b = ?, f_a = EXCLUDED.f_a
+`, "a", 1, 2))
+	})
+
+	t.Run("update excluded by columns", func(t *testing.T) {
+		addition := Upsert(table.MustCols("f_a")).UpdateExcluded(table.Col("f_b"))
+
+		gomega.NewWithT(t).Expect(exprFor("mysql", insert(addition))).To(BeExpr(`
+INSERT INTO T (f_a,f_b) VALUES (?,?)
+ON DUPLICATE KEY UPDATE f_b = VALUES(f_b)
+`, "a", 1))
+
+		gomega.NewWithT(t).Expect(exprFor("sqlite", insert(addition))).To(BeExpr(`
+INSERT INTO T (f_a,f_b) VALUES (?,?)
+ON CONFLICT (f_a) DO UPDATE SET f_b = EXCLUDED.f_b
+`, "a", 1))
+	})
+
+	t.Run("do nothing", func(t *testing.T) {
+		addition := Upsert(table.Key("i_a")).DoNothing()
+
+		gomega.NewWithT(t).Expect(exprFor("mysql", insert(addition))).To(BeExpr(`
+INSERT INTO T (f_a,f_b) VALUES (?,?)
+ON DUPLICATE KEY UPDATE f_a = f_a
+`, "a", 1))
+
+		gomega.NewWithT(t).Expect(insert(addition)).To(BeExpr(`
+INSERT INTO T (f_a,f_b) VALUES (?,?)
+ON CONFLICT (f_a) DO NOTHING
+`, "a", 1))
+	})
+
+	t.Run("nothing to update", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(insert(Upsert(table.Key("i_a")))).To(BeExpr(`
+INSERT INTO T (f_a,f_b) VALUES (?,?)
+`, "a", 1))
+	})
+}
@@ -0,0 +1,208 @@
+package builder
+
+import (
+	"context"
+	"strconv"
+)
+
+type WindowAddition struct {
+}
+
+func (WindowAddition) AdditionType() AdditionType {
+	return AdditionWindow
+}
+
+// NamedWindows WINDOW w AS (...)[, ...]
+func NamedWindows(namedWindows ...*namedWindow) *windows {
+	finalWindows := make([]*namedWindow, 0)
+
+	for i := range namedWindows {
+		if IsNilExpr(namedWindows[i]) {
+			continue
+		}
+		finalWindows = append(finalWindows, namedWindows[i])
+	}
+
+	return &windows{
+		windows: finalWindows,
+	}
+}
+
+var _ Addition = (*windows)(nil)
+
+type windows struct {
+	WindowAddition
+	windows []*namedWindow
+}
+
+func (w *windows) IsNil() bool {
+	return w == nil || len(w.windows) == 0
+}
+
+func (w *windows) Ex(ctx context.Context) *Ex {
+	e := Expr("WINDOW ")
+	for i := range w.windows {
+		if i > 0 {
+			e.WriteQueryByte(',')
+		}
+		e.WriteExpr(w.windows[i])
+	}
+	return e.Ex(ctx)
+}
+
+func NamedWindow(name string, w *window) *namedWindow {
+	return &namedWindow{name: name, window: w}
+}
+
+type namedWindow struct {
+	name   string
+	window *window
+}
+
+func (w *namedWindow) IsNil() bool {
+	return w == nil || w.name == "" || w.window == nil
+}
+
+func (w *namedWindow) Ex(ctx context.Context) *Ex {
+	e := Expr(w.name)
+	e.WriteQuery(" AS ")
+	e.WriteExpr(w.window)
+	return e.Ex(ctx)
+}
+
+// Window window definition for OVER or WINDOW
+func Window() *window {
+	return &window{}
+}
+
+// WindowOf refer to the named window
+func WindowOf(name string) *window {
+	return &window{name: name}
+}
+
+type window struct {
+	// existing window name
+	name        string
+	partitionBy []SqlExpr
+	orderBy     []*Order
+	frame       SqlExpr
+}
+
+func (w window) PartitionBy(exprs ...SqlExpr) *window {
+	w.partitionBy = exprs
+	return &w
+}
+
+func (w window) OrderBy(orders ...*Order) *window {
+	w.orderBy = orders
+	return &w
+}
+
+// Rows ROWS start or ROWS BETWEEN start AND end
+func (w window) Rows(bounds ...SqlExpr) *window {
+	w.frame = frame("ROWS", bounds...)
+	return &w
+}
+
+// Range RANGE start or RANGE BETWEEN start AND end
+func (w window) Range(bounds ...SqlExpr) *window {
+	w.frame = frame("RANGE", bounds...)
+	return &w
+}
+
+func (w *window) IsNil() bool {
+	return w == nil
+}
+
+func (w *window) Ex(ctx context.Context) *Ex {
+	if w.name != "" && len(w.partitionBy) == 0 && len(w.orderBy) == 0 && IsNilExpr(w.frame) {
+		return ExactlyExpr(w.name).Ex(ctx)
+	}
+
+	e := Expr("")
+
+	e.WriteGroup(func(e *Ex) {
+		needSpace := false
+
+		writeSpace := func() {
+			if needSpace {
+				e.WriteQueryByte(' ')
+			}
+			needSpace = true
+		}
+
+		if w.name != "" {
+			writeSpace()
+			e.WriteQuery(w.name)
+		}
+
+		if len(w.partitionBy) > 0 {
+			writeSpace()
+			e.WriteQuery("PARTITION BY ")
+			for i := range w.partitionBy {
+				if i > 0 {
+					e.WriteQueryByte(',')
+				}
+				e.WriteExpr(w.partitionBy[i])
+			}
+		}
+
+		if len(w.orderBy) > 0 {
+			writeSpace()
+			e.WriteQuery("ORDER BY ")
+			for i := range w.orderBy {
+				if i > 0 {
+					e.WriteQueryByte(',')
+				}
+				e.WriteExpr(w.orderBy[i])
+			}
+		}
+
+		if !IsNilExpr(w.frame) {
+			writeSpace()
+			e.WriteExpr(w.frame)
+		}
+	})
+
+	return e.Ex(ctx)
+}
+
+func frame(unit string, bounds ...SqlExpr) SqlExpr {
+	if len(bounds) == 0 {
+		return nil
+	}
+
+	e := Expr(unit)
+
+	if len(bounds) == 1 {
+		e.WriteQueryByte(' ')
+		e.WriteExpr(bounds[0])
+		return e
+	}
+
+	e.WriteQuery(" BETWEEN ")
+	e.WriteExpr(bounds[0])
+	e.WriteQuery(" AND ")
+	e.WriteExpr(bounds[1])
+	return e
+}
+
+func UnboundedPreceding() SqlExpr {
+	return Expr("UNBOUNDED PRECEDING")
+}
+
+func UnboundedFollowing() SqlExpr {
+	return Expr("UNBOUNDED FOLLOWING")
+}
+
+func CurrentRow() SqlExpr {
+	return Expr("CURRENT ROW")
+}
+
+func Preceding(n int) SqlExpr {
+	return Expr(strconv.Itoa(n) + " PRECEDING")
+}
+
+func Following(n int) SqlExpr {
+	return Expr(strconv.Itoa(n) + " FOLLOWING")
+}
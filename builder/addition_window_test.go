@@ -0,0 +1,87 @@
+package builder_test
+
+import (
+	"testing"
+
+	. "github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/kunlun-qilian/sqlx/v3/builder/buidertestingutils"
+	"github.com/onsi/gomega"
+)
+
+func TestWindow(t *testing.T) {
+	table := T("T")
+
+	t.Run("row number over partition", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(MultiWith(",",
+				Col("F_a"),
+				Alias(RowNumber().Over(Window().PartitionBy(Col("F_a")).OrderBy(DescOrder(Col("F_b")))), "f_rn"),
+			)).
+				From(
+					table,
+					Where(Col("F_a").Eq(1)),
+				),
+		).To(BeExpr(`
+SELECT f_a,ROW_NUMBER() OVER (PARTITION BY f_a ORDER BY (f_b) DESC) AS f_rn FROM T
+WHERE f_a = ?
+`,
+			1,
+		))
+	})
+
+	t.Run("running total with frame", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(
+				Sum(Col("F_amount")).Over(Window().OrderBy(AscOrder(Col("F_created_at"))).Rows(UnboundedPreceding(), CurrentRow())),
+			).
+				From(table),
+		).To(BeExpr(`
+SELECT SUM(f_amount) OVER (ORDER BY (f_created_at) ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) FROM T
+`,
+		))
+	})
+
+	t.Run("lag lead ntile", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(MultiWith(",",
+				Lag(Col("F_a"), Expr("1"), Expr("?", 0)).Over(Window().OrderBy(AscOrder(Col("F_b")))),
+				Lead(Col("F_a")).Over(Window().OrderBy(AscOrder(Col("F_b")))),
+				FirstValue(Col("F_a")).Over(Window().PartitionBy(Col("F_c")).Range(Preceding(1))),
+				NTile(4).Over(Window()),
+				Rank().Over(Window()),
+				DenseRank().Over(Window()),
+			)).
+				From(table),
+		).To(BeExpr(`
+SELECT LAG(f_a,1,?) OVER (ORDER BY (f_b) ASC),LEAD(f_a) OVER (ORDER BY (f_b) ASC),FIRST_VALUE(f_a) OVER (PARTITION BY f_c RANGE 1 PRECEDING),NTILE(4) OVER (),RANK() OVER (),DENSE_RANK() OVER () FROM T
+`,
+			0,
+		))
+	})
+
+	t.Run("named window", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(MultiWith(",",
+				Rank().Over(WindowOf("w")),
+				Sum(Col("F_amount")).Over(WindowOf("w").Rows(Preceding(2), Following(2))),
+			)).
+				From(
+					table,
+					OrderBy(AscOrder(Col("F_a"))),
+					NamedWindows(
+						NamedWindow("w", Window().PartitionBy(Col("F_a")).OrderBy(AscOrder(Col("F_b")))),
+					),
+					Where(Col("F_a").Eq(1)),
+					GroupBy(Col("F_a"), Col("F_b"), Col("F_amount")),
+				),
+		).To(BeExpr(`
+SELECT RANK() OVER w,SUM(f_amount) OVER (w ROWS BETWEEN 2 PRECEDING AND 2 FOLLOWING) FROM T
+WHERE f_a = ?
+GROUP BY f_a,f_b,f_amount
+WINDOW w AS (PARTITION BY f_a ORDER BY (f_b) ASC)
+ORDER BY (f_a) ASC
+`,
+			1,
+		))
+	})
+}
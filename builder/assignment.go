@@ -81,7 +81,9 @@ func (a *Assignment) Ex(ctx context.Context) *Ex {
 
 		e.WriteQuery(" VALUES ")
 
-		groupCount := int(math.Round(float64(len(a.values)) / float64(a.lenOfColumn)))
+		values := chunkValues(ctx, a, a.values, a.lenOfColumn)
+
+		groupCount := int(math.Round(float64(len(values)) / float64(a.lenOfColumn)))
 
 		for i := 0; i < groupCount; i++ {
 			if i > 0 {
@@ -95,7 +97,7 @@ func (a *Assignment) Ex(ctx context.Context) *Ex {
 			})
 		}
 
-		e.AppendArgs(a.values...)
+		e.AppendArgs(values...)
 
 		return e.Ex(ctx)
 	}
@@ -0,0 +1,75 @@
+package builder
+
+import (
+	"context"
+)
+
+// Case CASE WHEN cond THEN result [...] [ELSE result] END
+func Case() *caseExpr {
+	return &caseExpr{}
+}
+
+// CaseOf CASE expr WHEN value THEN result [...] [ELSE result] END
+func CaseOf(expr SqlExpr) *caseExpr {
+	return &caseExpr{expr: expr}
+}
+
+var _ SqlExpr = (*caseExpr)(nil)
+
+type caseExpr struct {
+	expr     SqlExpr
+	whens    []*caseWhen
+	elseThen interface{}
+	hasElse  bool
+}
+
+type caseWhen struct {
+	condOrValue interface{}
+	then        interface{}
+}
+
+// When condOrValue should be SqlCondition for Case(), and value to compare for CaseOf(expr).
+// Both condOrValue and then could be SqlExpr or value as arg.
+func (c caseExpr) When(condOrValue interface{}, then interface{}) *caseExpr {
+	c.whens = append(append(make([]*caseWhen, 0, len(c.whens)+1), c.whens...), &caseWhen{condOrValue: condOrValue, then: then})
+	return &c
+}
+
+func (c caseExpr) Else(then interface{}) *caseExpr {
+	c.elseThen = then
+	c.hasElse = true
+	return &c
+}
+
+func (c *caseExpr) IsNil() bool {
+	return c == nil || len(c.whens) == 0
+}
+
+func (c *caseExpr) Ex(ctx context.Context) *Ex {
+	e := Expr("CASE")
+	e.Grow(2*len(c.whens) + 2)
+
+	if !IsNilExpr(c.expr) {
+		e.WriteQueryByte(' ')
+		e.WriteExpr(c.expr)
+	}
+
+	for i := range c.whens {
+		e.WriteQuery(" WHEN ?")
+		e.AppendArgs(c.whens[i].condOrValue)
+		e.WriteQuery(" THEN ?")
+		e.AppendArgs(c.whens[i].then)
+	}
+
+	if c.hasElse {
+		e.WriteQuery(" ELSE ?")
+		e.AppendArgs(c.elseThen)
+	}
+
+	e.WriteQuery(" END")
+
+	// never auto alias columns in case
+	return e.Ex(ContextWithToggles(ctx, Toggles{
+		ToggleNeedAutoAlias: false,
+	}))
+}
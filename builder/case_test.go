@@ -0,0 +1,82 @@
+package builder_test
+
+import (
+	"testing"
+
+	. "github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/kunlun-qilian/sqlx/v3/builder/buidertestingutils"
+	"github.com/onsi/gomega"
+)
+
+func TestCase(t *testing.T) {
+	table := T("T",
+		Col("F_a").Type(0, ""),
+		Col("F_b").Type(0, ""),
+	)
+
+	t.Run("empty", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(Case()).To(BeExpr(""))
+	})
+
+	t.Run("select case when", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(
+				Alias(
+					Case().
+						When(Col("F_a").Gt(10), "large").
+						When(Col("F_a").Gt(5), Col("F_b")).
+						Else("small"),
+					"f_size",
+				),
+			).From(table),
+		).To(BeExpr(`
+SELECT CASE WHEN f_a > ? THEN ? WHEN f_a > ? THEN f_b ELSE ? END AS f_size FROM T
+`, 10, "large", 5, "small"))
+	})
+
+	t.Run("case of in order by and group by", func(t *testing.T) {
+		status := CaseOf(Col("F_a")).When(1, 10).When(2, 20).Else(0)
+
+		gomega.NewWithT(t).Expect(
+			Select(nil).From(
+				table,
+				GroupBy(status),
+				OrderBy(AscOrder(status)),
+			),
+		).To(BeExpr(`
+SELECT * FROM T
+GROUP BY CASE f_a WHEN ? THEN ? WHEN ? THEN ? ELSE ? END
+ORDER BY (CASE f_a WHEN ? THEN ? WHEN ? THEN ? ELSE ? END) ASC
+`, 1, 10, 2, 20, 0, 1, 10, 2, 20, 0))
+	})
+
+	t.Run("update set", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Update(table).
+				Set(
+					Col("F_b").ValueBy(Case().When(Col("F_a").Eq(1), Col("F_b").Incr(1)).Else(Col("F_b"))),
+				).
+				Where(Col("F_a").In(1, 2)),
+		).To(BeExpr(`
+UPDATE T SET f_b = CASE WHEN f_a = ? THEN f_b + ? ELSE f_b END
+WHERE f_a IN (?,?)
+`, 1, 1, 1, 2))
+	})
+
+	t.Run("multi table with auto alias", func(t *testing.T) {
+		tOther := T("t_other", Col("f_c").Type(0, ""))
+
+		gomega.NewWithT(t).Expect(
+			Select(MultiMayAutoAlias(
+				table.Col("F_a"),
+				Case().When(table.Col("F_a").Eq(tOther.Col("f_c")), table.Col("F_b")).Else(0),
+			)).From(
+				table,
+				Join(tOther).On(table.Col("F_b").Eq(tOther.Col("f_c"))),
+			),
+		).To(BeExpr(`
+SELECT T.f_a AS f_a, CASE WHEN T.f_a = t_other.f_c THEN T.f_b ELSE ? END FROM T
+JOIN t_other ON T.f_b = t_other.f_c
+`, 0))
+	})
+}
@@ -0,0 +1,168 @@
+package builder
+
+import (
+	"context"
+	"fmt"
+)
+
+type contextKeyChunk struct{}
+
+// chunkCollector collects parts which could be rendered in chunks
+type chunkCollector struct {
+	parts []chunkPart
+}
+
+type chunkPart struct {
+	key  interface{}
+	n    int
+	step int
+}
+
+// chunkSelector selects values of the part to render
+type chunkSelector struct {
+	key    interface{}
+	offset int
+	size   int
+}
+
+// chunkValues returns values of the part should be rendered,
+// step is the count of values which could not be split, like columns of one row.
+func chunkValues(ctx context.Context, key interface{}, values []interface{}, step int) []interface{} {
+	switch c := ctx.Value(contextKeyChunk{}).(type) {
+	case *chunkCollector:
+		c.parts = append(c.parts, chunkPart{key: key, n: len(values), step: step})
+	case *chunkSelector:
+		if c.key == key {
+			end := c.offset + c.size
+			if end > len(values) {
+				end = len(values)
+			}
+			return values[c.offset:end]
+		}
+	}
+	return values
+}
+
+// ResolveExprInChunks resolve expr into exprs with args no more than maxArgs,
+// by rendering the largest IN list or multi-row VALUES in chunks.
+// other parts of expr will be kept in each chunk, so merging results of chunks is up to the caller.
+// returns single expr when maxArgs is not positive or args not over the limit,
+// and error when no part could be split without changing the merged result, see chunkableKeys.
+func ResolveExprInChunks(ctx context.Context, expr SqlExpr, maxArgs int) ([]*Ex, error) {
+	collector := &chunkCollector{}
+
+	e := ResolveExprContext(context.WithValue(ctx, contextKeyChunk{}, collector), expr)
+	if maxArgs <= 0 || IsNilExpr(e) || e.ArgsLen() <= maxArgs {
+		return []*Ex{e}, nil
+	}
+
+	keys := chunkableKeys(expr)
+
+	var part *chunkPart
+	for i := range collector.parts {
+		if p := collector.parts[i]; keys[p.key] && (part == nil || p.n > part.n) {
+			part = &p
+		}
+	}
+
+	if part == nil {
+		return nil, fmt.Errorf("count of args %d is over the limit %d, and could not be split", e.ArgsLen(), maxArgs)
+	}
+
+	size := (maxArgs - (e.ArgsLen() - part.n)) / part.step * part.step
+	if size < part.step {
+		return nil, fmt.Errorf("count of args %d is over the limit %d, and could not be split", e.ArgsLen(), maxArgs)
+	}
+
+	exprs := make([]*Ex, 0, part.n/size+1)
+
+	for offset := 0; offset < part.n; offset += size {
+		exprs = append(exprs, ResolveExprContext(
+			context.WithValue(ctx, contextKeyChunk{}, &chunkSelector{key: part.key, offset: offset, size: size}),
+			expr,
+		))
+	}
+
+	return exprs, nil
+}
+
+// chunkableKeys returns keys of parts, which rows of chunks could be merged as the result of the whole statement:
+// multi-row VALUES of INSERT,
+// and IN list as top-level AND condition of WHERE of SELECT, UPDATE or DELETE.
+// SELECT with modifiers like DISTINCT, aggregates, GROUP BY, window, combination, ORDER BY or LIMIT,
+// and UPDATE or DELETE with ORDER BY or LIMIT could not be split.
+func chunkableKeys(expr SqlExpr) map[interface{}]bool {
+	keys := map[interface{}]bool{}
+
+	switch s := expr.(type) {
+	case *StmtInsert:
+		for _, a := range s.assignments {
+			keys[a] = true
+		}
+	case *StmtSelect:
+		if len(s.modifiers) > 0 || !isPlainProjection(s.sqlExpr) ||
+			hasAdditionTypeOf(s.additions, AdditionGroupBy, AdditionWindow, AdditionCombination, AdditionOrderBy, AdditionLimit) {
+			return keys
+		}
+		collectWhereInKeys(s.additions, keys)
+	case *StmtUpdate:
+		if hasAdditionTypeOf(s.additions, AdditionOrderBy, AdditionLimit) {
+			return keys
+		}
+		collectWhereInKeys(s.additions, keys)
+	case *StmtDelete:
+		if hasAdditionTypeOf(s.additions, AdditionOrderBy, AdditionLimit) {
+			return keys
+		}
+		collectWhereInKeys(s.additions, keys)
+	}
+
+	return keys
+}
+
+// isPlainProjection returns true only for *, columns and aliases of them, which could not be aggregated
+func isPlainProjection(sqlExpr SqlExpr) bool {
+	if IsNilExpr(sqlExpr) {
+		return true
+	}
+
+	switch x := sqlExpr.(type) {
+	case *Column, *Columns:
+		return true
+	case *exAlias:
+		return isPlainProjection(x.SqlExpr)
+	case *exMayAutoAlias:
+		for _, col := range x.columns {
+			if !isPlainProjection(col) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func collectWhereInKeys(additions []Addition, keys map[interface{}]bool) {
+	for _, addition := range additions {
+		if w, ok := addition.(*where); ok && !w.IsNil() {
+			collectConjunctInKeys(w.condition, keys)
+		}
+	}
+}
+
+func collectConjunctInKeys(condition SqlCondition, keys map[interface{}]bool) {
+	switch c := condition.(type) {
+	case *ComposedCondition:
+		if c.op != "AND" {
+			return
+		}
+		for _, sub := range c.conditions {
+			collectConjunctInKeys(sub, keys)
+		}
+	case *Condition:
+		if in, ok := c.expr.(*columnIn); ok && !in.IsNil() {
+			keys[in.list] = true
+		}
+	}
+}
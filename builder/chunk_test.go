@@ -0,0 +1,112 @@
+package builder_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/kunlun-qilian/sqlx/v3/builder/buidertestingutils"
+	"github.com/onsi/gomega"
+)
+
+func TestResolveExprInChunks(t *testing.T) {
+	table := T("T",
+		Col("F_a").Type(0, ""),
+		Col("F_b").Type(0, ""),
+	)
+
+	t.Run("in list", func(t *testing.T) {
+		exprs, err := ResolveExprInChunks(context.Background(),
+			Select(nil).From(table, Where(
+				And(
+					Col("F_a").In(1, 2, 3, 4, 5),
+					Col("F_b").Eq(1),
+				),
+			)),
+			3,
+		)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(3))
+		gomega.NewWithT(t).Expect(exprs[0]).To(BeExpr(`SELECT * FROM T
+WHERE (f_a IN (?,?)) AND (f_b = ?)`, 1, 2, 1))
+		gomega.NewWithT(t).Expect(exprs[1]).To(BeExpr(`SELECT * FROM T
+WHERE (f_a IN (?,?)) AND (f_b = ?)`, 3, 4, 1))
+		gomega.NewWithT(t).Expect(exprs[2]).To(BeExpr(`SELECT * FROM T
+WHERE (f_a IN (?)) AND (f_b = ?)`, 5, 1))
+	})
+
+	t.Run("multi-row values", func(t *testing.T) {
+		exprs, err := ResolveExprInChunks(context.Background(),
+			Insert().Into(table).Values(Cols("F_a", "F_b"), 1, 1, 2, 2, 3, 3),
+			5,
+		)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[0]).To(BeExpr(`INSERT INTO T (f_a,f_b) VALUES (?,?),(?,?)`, 1, 1, 2, 2))
+		gomega.NewWithT(t).Expect(exprs[1]).To(BeExpr(`INSERT INTO T (f_a,f_b) VALUES (?,?)`, 3, 3))
+	})
+
+	t.Run("under limit", func(t *testing.T) {
+		exprs, err := ResolveExprInChunks(context.Background(),
+			Select(nil).From(table, Where(Col("F_a").In(1, 2, 3))),
+			3,
+		)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(1))
+		gomega.NewWithT(t).Expect(exprs[0]).To(BeExpr(`SELECT * FROM T
+WHERE f_a IN (?,?,?)`, 1, 2, 3))
+	})
+
+	t.Run("in list of update and delete", func(t *testing.T) {
+		exprs, err := ResolveExprInChunks(context.Background(),
+			Update(table).Set(Col("F_b").ValueBy(1)).Where(Col("F_a").In(1, 2, 3, 4)),
+			3,
+		)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[1]).To(BeExpr(`UPDATE T SET f_b = ?
+WHERE f_a IN (?,?)`, 1, 3, 4))
+
+		exprs, err = ResolveExprInChunks(context.Background(),
+			Delete().From(table, Where(Col("F_a").In(1, 2, 3, 4))),
+			3,
+		)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(exprs).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(exprs[1]).To(BeExpr(`DELETE FROM T
+WHERE f_a IN (?)`, 4))
+	})
+
+	t.Run("could not split", func(t *testing.T) {
+		_, err := ResolveExprInChunks(context.Background(),
+			Select(nil).From(table, Where(Col("F_a").NotIn(1, 2, 3))),
+			2,
+		)
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+
+	inList := Col("F_a").In(1, 2, 3, 4, 5)
+
+	for name, expr := range map[string]SqlExpr{
+		"count":              Select(Count()).From(table, Where(inList)),
+		"aggregate":          Select(Sum(Col("F_b"))).From(table, Where(inList)),
+		"aggregate as alias": Select(Alias(Max(Col("F_b")), "max")).From(table, Where(inList)),
+		"raw projection":     Select(Expr("COUNT(DISTINCT f_b)")).From(table, Where(inList)),
+		"or":                 Select(nil).From(table, Where(Or(inList, Col("F_b").Eq(1)))),
+		"limit":              Select(nil).From(table, Where(inList), Limit(2)),
+		"limit and offset":   Select(nil).From(table, Where(inList), Limit(2).Offset(1)),
+		"order by":           Select(nil).From(table, Where(inList), OrderBy(AscOrder(Col("F_b")))),
+		"distinct":           Select(Col("F_b"), "DISTINCT").From(table, Where(inList)),
+		"group by":           Select(Col("F_b")).From(table, Where(inList), GroupBy(Col("F_b"))),
+		"combination":        Select(nil).From(table, Where(inList), Union().All(Select(nil).From(table))),
+		"sub query":          Select(nil).From(table, Where(Col("F_b").In(Select(Col("F_b")).From(table, Where(inList))))),
+		"update with limit":  Update(table).Set(Col("F_b").ValueBy(1)).Where(inList, Limit(2)),
+		"delete with order":  Delete().From(table, Where(inList), OrderBy(AscOrder(Col("F_b"))), Limit(2)),
+		"raw expr":           Expr("SELECT * FROM T WHERE ?", inList),
+	} {
+		t.Run("could not split "+name, func(t *testing.T) {
+			_, err := ResolveExprInChunks(context.Background(), expr, 3)
+			gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		})
+	}
+}
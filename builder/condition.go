@@ -151,3 +151,50 @@ func (c *ComposedCondition) Ex(ctx context.Context) *Ex {
 
 	return e.Ex(ctx)
 }
+
+// Exists EXISTS (SELECT ...)
+func Exists(stmtSelect SelectStatement) SqlCondition {
+	if IsNilExpr(stmtSelect) {
+		return nil
+	}
+	return AsCond(Expr("EXISTS ?", SubQuery(stmtSelect)))
+}
+
+// NotExists NOT EXISTS (SELECT ...)
+func NotExists(stmtSelect SelectStatement) SqlCondition {
+	if IsNilExpr(stmtSelect) {
+		return nil
+	}
+	return AsCond(Expr("NOT EXISTS ?", SubQuery(stmtSelect)))
+}
+
+// Any ANY (SELECT ...), for comparison like col > ANY (SELECT ...)
+func Any(stmtSelect SelectStatement) SqlExpr {
+	return Expr("ANY ?", SubQuery(stmtSelect))
+}
+
+// All ALL (SELECT ...), for comparison like col > ALL (SELECT ...)
+func All(stmtSelect SelectStatement) SqlExpr {
+	return Expr("ALL ?", SubQuery(stmtSelect))
+}
+
+// SubQuery wrap select statement with parentheses.
+// columns of outer query could be referred by Column.Of for correlated sub query.
+func SubQuery(stmtSelect SelectStatement) SqlExpr {
+	return ExprBy(func(ctx context.Context) *Ex {
+		e := Expr("")
+		e.WriteGroup(func(e *Ex) {
+			e.WriteExpr(stmtSelect)
+		})
+		return e.Ex(ContextWithToggles(ctx, Toggles{
+			ToggleNeedAutoAlias: false,
+		}))
+	})
+}
+
+func subQueryOrValue(v interface{}) interface{} {
+	if stmtSelect, ok := v.(SelectStatement); ok {
+		return SubQuery(stmtSelect)
+	}
+	return v
+}
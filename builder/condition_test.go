@@ -206,3 +206,78 @@ func TestConditions(t *testing.T) {
 		))
 	})
 }
+
+func TestSubQuery(t *testing.T) {
+	tUser := T("t_user",
+		Col("f_id").Type(uint64(0), ",autoincrement"),
+		Col("f_org_id").Type(uint64(0), ""),
+		Col("f_age").Type(0, ""),
+	)
+
+	tOrg := T("t_org",
+		Col("f_org_id").Type(uint64(0), ",autoincrement"),
+		Col("f_enabled").Type(false, ""),
+	)
+
+	t.Run("exists with correlated column", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Select(nil).From(
+				tUser,
+				Where(
+					Exists(
+						Select(Expr("1")).From(
+							tOrg,
+							Where(
+								And(
+									tOrg.Col("f_org_id").Eq(tUser.Col("f_org_id").Of(tUser)),
+									tOrg.Col("f_enabled").Eq(true),
+								),
+							),
+						),
+					),
+				),
+			),
+		).To(BeExpr(`
+SELECT * FROM t_user
+WHERE EXISTS (SELECT 1 FROM t_org
+WHERE (f_org_id = t_user.f_org_id) AND (f_enabled = ?))
+`, true))
+	})
+
+	t.Run("not exists", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			NotExists(Select(nil).From(tOrg)),
+		).To(BeExpr(`NOT EXISTS (SELECT * FROM t_org)`))
+	})
+
+	t.Run("in and not in", func(t *testing.T) {
+		sub := Select(tOrg.Col("f_org_id")).From(tOrg, Where(tOrg.Col("f_enabled").Eq(true)))
+
+		gomega.NewWithT(t).Expect(
+			tUser.Col("f_org_id").In(sub),
+		).To(BeExpr(`f_org_id IN (SELECT f_org_id FROM t_org
+WHERE f_enabled = ?)`, true))
+
+		gomega.NewWithT(t).Expect(
+			tUser.Col("f_org_id").NotIn(sub),
+		).To(BeExpr(`f_org_id NOT IN (SELECT f_org_id FROM t_org
+WHERE f_enabled = ?)`, true))
+	})
+
+	t.Run("comparisons", func(t *testing.T) {
+		sub := Select(Max(tUser.Col("f_age"))).From(tUser)
+
+		gomega.NewWithT(t).Expect(
+			tUser.Col("f_age").Eq(sub),
+		).To(BeExpr(`f_age = (SELECT MAX(f_age) FROM t_user)`))
+
+		gomega.NewWithT(t).Expect(
+			tUser.Col("f_age").Gt(Any(Select(tUser.Col("f_age")).From(tUser, Where(tUser.Col("f_org_id").Eq(1))))),
+		).To(BeExpr(`f_age > ANY (SELECT f_age FROM t_user
+WHERE f_org_id = ?)`, 1))
+
+		gomega.NewWithT(t).Expect(
+			tUser.Col("f_age").Lte(All(Select(tUser.Col("f_age")).From(tUser))),
+		).To(BeExpr(`f_age <= ALL (SELECT f_age FROM t_user)`))
+	})
+}
@@ -117,6 +117,16 @@ func (c *Column) Like(v string) SqlCondition {
 	return AsCond(Expr("? LIKE ?", c, "%"+v+"%"))
 }
 
+// ILike case-insensitive LIKE, ILIKE for postgres, LIKE for others which is case-insensitive by default
+func (c *Column) ILike(v string) SqlCondition {
+	return AsCond(ExprBy(func(ctx context.Context) *Ex {
+		if driverNameFromContext(ctx) == "postgres" {
+			return Expr("? ILIKE ?", c, "%"+v+"%").Ex(ctx)
+		}
+		return Expr("? LIKE ?", c, "%"+v+"%").Ex(ctx)
+	}))
+}
+
 func (c *Column) LeftLike(v string) SqlCondition {
 	return AsCond(Expr("? LIKE ?", c, "%"+v))
 }
@@ -159,23 +169,52 @@ func (c *Column) In(args ...interface{}) SqlCondition {
 		if withConditionFor, ok := args[0].(WithConditionFor); ok {
 			return withConditionFor.ConditionFor(c)
 		}
+		if stmtSelect, ok := args[0].(SelectStatement); ok {
+			return AsCond(Expr("? IN ?", c, SubQuery(stmtSelect)))
+		}
 	}
 
-	e := Expr("? IN ")
+	return AsCond(&columnIn{column: c, list: &valueList{values: args}})
+}
 
-	e.Grow(n + 1)
+// columnIn col IN (?,?,...), the value list could be rendered in chunks when it is a top-level AND condition of WHERE
+type columnIn struct {
+	column *Column
+	list   *valueList
+}
 
-	e.AppendArgs(c)
+func (c *columnIn) IsNil() bool {
+	return c == nil || IsNilExpr(c.list)
+}
+
+func (c *columnIn) Ex(ctx context.Context) *Ex {
+	return Expr("? IN ?", c.column, c.list).Ex(ctx)
+}
+
+// valueList renders (?,?,...) of values, which could be rendered in chunks when too many args
+type valueList struct {
+	values []interface{}
+}
+
+func (l *valueList) IsNil() bool {
+	return l == nil || len(l.values) == 0
+}
+
+func (l *valueList) Ex(ctx context.Context) *Ex {
+	values := chunkValues(ctx, l, l.values, 1)
+
+	e := Expr("")
+	e.Grow(len(values))
 
 	e.WriteGroup(func(e *Ex) {
-		for i := 0; i < n; i++ {
+		for i := range values {
 			e.WriteHolder(i)
 		}
 	})
 
-	e.AppendArgs(args...)
+	e.AppendArgs(values...)
 
-	return AsCond(e)
+	return e.Ex(ctx)
 }
 
 func (c *Column) NotIn(args ...interface{}) SqlCondition {
@@ -184,6 +223,12 @@ func (c *Column) NotIn(args ...interface{}) SqlCondition {
 		return nil
 	}
 
+	if n == 1 {
+		if stmtSelect, ok := args[0].(SelectStatement); ok {
+			return AsCond(Expr("? NOT IN ?", c, SubQuery(stmtSelect)))
+		}
+	}
+
 	e := Expr("")
 	e.Grow(n + 1)
 
@@ -202,25 +247,25 @@ func (c *Column) NotIn(args ...interface{}) SqlCondition {
 }
 
 func (c *Column) Eq(v interface{}) SqlCondition {
-	return AsCond(Expr("? = ?", c, v))
+	return AsCond(Expr("? = ?", c, subQueryOrValue(v)))
 }
 
 func (c *Column) Neq(v interface{}) SqlCondition {
-	return AsCond(Expr("? <> ?", c, v))
+	return AsCond(Expr("? <> ?", c, subQueryOrValue(v)))
 }
 
 func (c *Column) Gt(v interface{}) SqlCondition {
-	return AsCond(Expr("? > ?", c, v))
+	return AsCond(Expr("? > ?", c, subQueryOrValue(v)))
 }
 
 func (c *Column) Gte(v interface{}) SqlCondition {
-	return AsCond(Expr("? >= ?", c, v))
+	return AsCond(Expr("? >= ?", c, subQueryOrValue(v)))
 }
 
 func (c *Column) Lt(v interface{}) SqlCondition {
-	return AsCond(Expr("? < ?", c, v))
+	return AsCond(Expr("? < ?", c, subQueryOrValue(v)))
 }
 
 func (c *Column) Lte(v interface{}) SqlCondition {
-	return AsCond(Expr("? <= ?", c, v))
+	return AsCond(Expr("? <= ?", c, subQueryOrValue(v)))
 }
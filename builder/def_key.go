@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -109,6 +110,54 @@ func (key *Key) IsPrimary() bool {
 	return key.IsUnique && key.Name == "primary" || strings.HasSuffix(key.Name, "pkey")
 }
 
+// FieldNames returns struct field names of the key,
+// resolved by column names or #FieldName in expr when not defined by field names.
+func (key *Key) FieldNames() []string {
+	if len(key.Def.FieldNames) != 0 {
+		return key.Def.FieldNames
+	}
+
+	fieldNames := make([]string, 0)
+
+	if len(key.Def.ColNames) != 0 {
+		if key.Table != nil {
+			for _, colName := range key.Def.ColNames {
+				if col := key.Table.Col(colName); col != nil {
+					fieldNames = append(fieldNames, col.FieldName)
+				}
+			}
+		}
+		return fieldNames
+	}
+
+	for _, matched := range fieldNameInExprRegexp.FindAllStringSubmatch(key.Def.Expr, -1) {
+		fieldNames = append(fieldNames, matched[1])
+	}
+
+	return fieldNames
+}
+
+// ColNames returns column names of the key
+func (key *Key) ColNames() []string {
+	if len(key.Def.ColNames) != 0 {
+		return key.Def.ColNames
+	}
+
+	colNames := make([]string, 0)
+
+	if key.Table != nil {
+		for _, fieldName := range key.FieldNames() {
+			if col := key.Table.F(fieldName); col != nil {
+				colNames = append(colNames, col.Name)
+			}
+		}
+	}
+
+	return colNames
+}
+
+var fieldNameInExprRegexp = regexp.MustCompile(`#(\w+)`)
+
 func (key *Key) IsPartition() bool {
 	return key.Name == "partition"
 }
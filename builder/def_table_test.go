@@ -92,3 +92,31 @@ WHERE t_user.f_id > 1
 		})
 	})
 }
+
+func TestKey_FieldNames(t *testing.T) {
+	tUser := T("t_user",
+		Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+		Col("f_name").Field("Name").Type("", ",size=128,default=''"),
+		Col("f_org_id").Field("OrgID").Type(uint64(0), ""),
+	)
+
+	tUser.AddKey(&Key{Name: "i_name", IsUnique: true, Def: *ParseIndexDef("OrgID", "Name")})
+	tUser.AddKey(&Key{Name: "i_col", IsUnique: true, Def: IndexDef{ColNames: []string{"f_name"}}})
+	tUser.AddKey(&Key{Name: "i_expr", Def: *ParseIndexDef("(#Name gist_trgm_ops)")})
+
+	t.Run("by field names", func(t *testing.T) {
+		key := tUser.Keys.Key("i_name")
+		gomega.NewWithT(t).Expect(key.FieldNames()).To(gomega.Equal([]string{"OrgID", "Name"}))
+		gomega.NewWithT(t).Expect(key.ColNames()).To(gomega.Equal([]string{"f_org_id", "f_name"}))
+	})
+	t.Run("by col names", func(t *testing.T) {
+		key := tUser.Keys.Key("i_col")
+		gomega.NewWithT(t).Expect(key.FieldNames()).To(gomega.Equal([]string{"Name"}))
+		gomega.NewWithT(t).Expect(key.ColNames()).To(gomega.Equal([]string{"f_name"}))
+	})
+	t.Run("by expr", func(t *testing.T) {
+		key := tUser.Keys.Key("i_expr")
+		gomega.NewWithT(t).Expect(key.FieldNames()).To(gomega.Equal([]string{"Name"}))
+		gomega.NewWithT(t).Expect(key.ColNames()).To(gomega.Equal([]string{"f_name"}))
+	})
+}
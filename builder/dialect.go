@@ -0,0 +1,36 @@
+package builder
+
+import (
+	"context"
+
+	contextx "github.com/go-courier/x/context"
+)
+
+type contextKeyForDialect struct {
+}
+
+// ContextWithDialect set the dialect of the executing db for building
+func ContextWithDialect(ctx context.Context, dialect Dialect) context.Context {
+	return contextx.WithValue(ctx, contextKeyForDialect{}, dialect)
+}
+
+// DialectFromContext return the dialect of the executing db, nil when not set.
+// Limit, Upsert, Bool, StringAgg and ILike branch on it.
+// Placeholders are still rendered as ? for every dialect,
+// the postgres connector rewrites them to $N, which raw queries out of builder rely on too.
+func DialectFromContext(ctx context.Context) Dialect {
+	if ctx == nil {
+		return nil
+	}
+	if dialect, ok := ctx.Value(contextKeyForDialect{}).(Dialect); ok {
+		return dialect
+	}
+	return nil
+}
+
+func driverNameFromContext(ctx context.Context) string {
+	if dialect := DialectFromContext(ctx); dialect != nil {
+		return dialect.DriverName()
+	}
+	return ""
+}
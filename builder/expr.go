@@ -104,6 +104,8 @@ type Ex struct {
 	args    []interface{}
 	err     error
 	exactly bool
+	// offsets of holders written by WriteHolder, which never be part of the escaped ??
+	holders []int
 }
 
 func (e *Ex) IsNil() bool {
@@ -193,6 +195,7 @@ func (e *Ex) WriteHolder(idx int) {
 	if idx > 0 {
 		e.b.WriteByte(',')
 	}
+	e.holders = append(e.holders, e.b.Len())
 	e.b.WriteByte('?')
 }
 
@@ -229,10 +232,24 @@ func (e *Ex) Ex(ctx context.Context) *Ex {
 	}
 
 	argIndex := 0
+	holderIndex := 0
 
-	for i := range query {
+	isHolder := func(i int) bool {
+		for holderIndex < len(e.holders) && e.holders[holderIndex] < i {
+			holderIndex++
+		}
+		return holderIndex < len(e.holders) && e.holders[holderIndex] == i
+	}
+
+	for i := 0; i < len(query); i++ {
 		switch c := query[i]; c {
 		case '?':
+			// ?? in query is the escaped operator ? (like jsonb ?? of postgres), kept for the driver
+			if i+1 < len(query) && query[i+1] == '?' && !isHolder(i) && !isHolder(i+1) {
+				eb.WriteQuery("??")
+				i++
+				continue
+			}
 			if argIndex >= n {
 				panic(fmt.Errorf("missing arg %d of %s", argIndex, query))
 			}
@@ -58,6 +58,12 @@ func TestEx(t *testing.T) {
 			Expr(`#Point = ?`, Point{1, 1}),
 		).To(BeExpr("#Point = ST_GeomFromText(?)", Point{1, 1}))
 	})
+
+	t.Run("escaped operator ??", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			Expr(`? ?? ? AND ? ??| ?`, Col("f_data"), "key", Col("f_data"), "{a,b}"),
+		).To(BeExpr("f_data ?? ? AND f_data ??| ?", "key", "{a,b}"))
+	})
 }
 
 func BenchmarkEx(b *testing.B) {
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"context"
+	"strconv"
 )
 
 func Count(sqlExprs ...SqlExpr) *Function {
@@ -39,6 +40,76 @@ func Sum(sqlExprs ...SqlExpr) *Function {
 	return Func("SUM", sqlExprs...)
 }
 
+func RowNumber() *Function {
+	return funcWithoutArgs("ROW_NUMBER")
+}
+
+func Rank() *Function {
+	return funcWithoutArgs("RANK")
+}
+
+func DenseRank() *Function {
+	return funcWithoutArgs("DENSE_RANK")
+}
+
+// Lag LAG(expr[, offset[, default]])
+func Lag(sqlExpr SqlExpr, offsetAndDefault ...SqlExpr) *Function {
+	return Func("LAG", append([]SqlExpr{sqlExpr}, offsetAndDefault...)...)
+}
+
+// Lead LEAD(expr[, offset[, default]])
+func Lead(sqlExpr SqlExpr, offsetAndDefault ...SqlExpr) *Function {
+	return Func("LEAD", append([]SqlExpr{sqlExpr}, offsetAndDefault...)...)
+}
+
+func FirstValue(sqlExpr SqlExpr) *Function {
+	return Func("FIRST_VALUE", sqlExpr)
+}
+
+func LastValue(sqlExpr SqlExpr) *Function {
+	return Func("LAST_VALUE", sqlExpr)
+}
+
+func NTile(buckets int) *Function {
+	return Func("NTILE", Expr(strconv.Itoa(buckets)))
+}
+
+// StringAgg concat values of group with separator, rendered by the dialect in context
+func StringAgg(sqlExpr SqlExpr, separator string) SqlExpr {
+	return ExprBy(func(ctx context.Context) *Ex {
+		switch driverNameFromContext(ctx) {
+		case "mysql":
+			return Expr("GROUP_CONCAT(? SEPARATOR ?)", sqlExpr, separator).Ex(ctx)
+		case "sqlite":
+			return Expr("GROUP_CONCAT(?,?)", sqlExpr, separator).Ex(ctx)
+		}
+		return Expr("STRING_AGG(?,?)", sqlExpr, separator).Ex(ctx)
+	})
+}
+
+// Bool boolean literal rendered by the dialect in context, TRUE/FALSE for postgres and 1/0 for others
+func Bool(b bool) SqlExpr {
+	return ExprBy(func(ctx context.Context) *Ex {
+		if driverNameFromContext(ctx) == "postgres" {
+			if b {
+				return Expr("TRUE")
+			}
+			return Expr("FALSE")
+		}
+		if b {
+			return Expr("1")
+		}
+		return Expr("0")
+	})
+}
+
+func funcWithoutArgs(name string) *Function {
+	return &Function{
+		name:   name,
+		noArgs: true,
+	}
+}
+
 func Func(name string, sqlExprs ...SqlExpr) *Function {
 	if name == "" {
 		return nil
@@ -50,8 +121,17 @@ func Func(name string, sqlExprs ...SqlExpr) *Function {
 }
 
 type Function struct {
-	name  string
-	exprs []SqlExpr
+	name   string
+	exprs  []SqlExpr
+	noArgs bool
+	// OVER
+	window *window
+}
+
+// Over make function as window function
+func (f Function) Over(w *window) *Function {
+	f.window = w
+	return &f
 }
 
 func (f *Function) IsNil() bool {
@@ -62,7 +142,7 @@ func (f *Function) Ex(ctx context.Context) *Ex {
 	e := Expr(f.name)
 
 	e.WriteGroup(func(e *Ex) {
-		if len(f.exprs) == 0 {
+		if len(f.exprs) == 0 && !f.noArgs {
 			e.WriteQueryByte('*')
 		}
 
@@ -74,5 +154,10 @@ func (f *Function) Ex(ctx context.Context) *Ex {
 		}
 	})
 
+	if f.window != nil {
+		e.WriteQuery(" OVER ")
+		e.WriteExpr(f.window)
+	}
+
 	return e.Ex(ctx)
 }
@@ -1,6 +1,7 @@
 package builder_test
 
 import (
+	"context"
 	"testing"
 
 	. "github.com/kunlun-qilian/sqlx/v3/builder"
@@ -19,3 +20,43 @@ func TestFunc(t *testing.T) {
 		gomega.NewWithT(t).Expect(Avg()).To(BeExpr("AVG(*)"))
 	})
 }
+
+func TestFuncWithDialect(t *testing.T) {
+	exprFor := func(driverName string, expr SqlExpr) SqlExpr {
+		return ExprBy(func(ctx context.Context) *Ex {
+			return expr.Ex(ContextWithDialect(ctx, &dialectWithDriverName{driverName: driverName}))
+		})
+	}
+
+	t.Run("string agg", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(exprFor("mysql", StringAgg(Col("f_a"), ","))).
+			To(BeExpr("GROUP_CONCAT(f_a SEPARATOR ?)", ","))
+		gomega.NewWithT(t).Expect(exprFor("sqlite", StringAgg(Col("f_a"), ","))).
+			To(BeExpr("GROUP_CONCAT(f_a,?)", ","))
+		gomega.NewWithT(t).Expect(exprFor("postgres", StringAgg(Col("f_a"), ","))).
+			To(BeExpr("STRING_AGG(f_a,?)", ","))
+	})
+
+	t.Run("ilike", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(exprFor("postgres", Col("f_a").ILike("a"))).
+			To(BeExpr("f_a ILIKE ?", "%a%"))
+		gomega.NewWithT(t).Expect(exprFor("mysql", Col("f_a").ILike("a"))).
+			To(BeExpr("f_a LIKE ?", "%a%"))
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(exprFor("postgres", Col("f_enabled").Eq(Bool(true)))).
+			To(BeExpr("f_enabled = TRUE"))
+		gomega.NewWithT(t).Expect(exprFor("mysql", Col("f_enabled").Eq(Bool(false)))).
+			To(BeExpr("f_enabled = 0"))
+		gomega.NewWithT(t).Expect(exprFor("sqlite", Col("f_enabled").Eq(Bool(true)))).
+			To(BeExpr("f_enabled = 1"))
+	})
+
+	t.Run("dialect from context", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(DialectFromContext(context.Background())).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(
+			DialectFromContext(ContextWithDialect(context.Background(), &dialectWithDriverName{driverName: "mysql"})).DriverName(),
+		).To(gomega.Equal("mysql"))
+	})
+}
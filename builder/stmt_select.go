@@ -18,10 +18,11 @@ func Select(sqlExpr SqlExpr, modifiers ...string) *StmtSelect {
 
 type StmtSelect struct {
 	SelectStatement
-	sqlExpr   SqlExpr
-	table     *Table
-	modifiers []string
-	additions []Addition
+	sqlExpr     SqlExpr
+	table       *Table
+	modifiers   []string
+	additions   []Addition
+	primaryOnly bool
 }
 
 func (s *StmtSelect) IsNil() bool {
@@ -84,5 +85,118 @@ func (s *StmtSelect) Ex(ctx context.Context) *Ex {
 }
 
 func ForUpdate() *OtherAddition {
-	return AsAddition(Expr("FOR UPDATE"))
+	return &OtherAddition{
+		SqlExpr: Expr("FOR UPDATE"),
+		locking: true,
+	}
+}
+
+// PrimaryOnly marks the statement should not be executed on replicas,
+// like SELECT nextval('seq') or SELECT pg_advisory_lock(1).
+func (s StmtSelect) PrimaryOnly() *StmtSelect {
+	s.primaryOnly = true
+	return &s
+}
+
+// IsPrimaryOnly returns true when marked by PrimaryOnly or with locking addition like ForUpdate
+func (s *StmtSelect) IsPrimaryOnly() bool {
+	if s.primaryOnly {
+		return true
+	}
+	for i := range s.additions {
+		if a, ok := s.additions[i].(*OtherAddition); ok && !a.IsNil() && a.locking {
+			return true
+		}
+	}
+	return false
+}
+
+// Orders returns orders of ORDER BY additions
+func (s *StmtSelect) Orders() []*Order {
+	orders := make([]*Order, 0)
+	for i := range s.additions {
+		if o, ok := s.additions[i].(*orderBy); ok && !o.IsNil() {
+			orders = append(orders, o.orders...)
+		}
+	}
+	return orders
+}
+
+// WithoutAdditions returns the statement without additions of types, like ORDER BY and LIMIT for counting
+func (s StmtSelect) WithoutAdditions(additionTypes ...AdditionType) *StmtSelect {
+	additions := make([]Addition, 0, len(s.additions))
+	for i := range s.additions {
+		addition := s.additions[i]
+		if IsNilExpr(addition) || isAdditionTypeOf(addition, additionTypes...) {
+			continue
+		}
+		additions = append(additions, addition)
+	}
+	s.additions = additions
+	return &s
+}
+
+// WithAdditions returns the statement with more additions,
+// condition of WHERE will be composed with the existed one by AND.
+func (s StmtSelect) WithAdditions(additions ...Addition) *StmtSelect {
+	finalAdditions := append(make([]Addition, 0, len(s.additions)+len(additions)), s.additions...)
+
+	for i := range additions {
+		addition := additions[i]
+		if IsNilExpr(addition) {
+			continue
+		}
+
+		if w, ok := addition.(*where); ok {
+			composed := false
+			for j := range finalAdditions {
+				if prev, ok := finalAdditions[j].(*where); ok && !prev.IsNil() {
+					finalAdditions[j] = Where(And(prev.condition, w.condition))
+					composed = true
+					break
+				}
+			}
+			if composed {
+				continue
+			}
+		}
+
+		finalAdditions = append(finalAdditions, addition)
+	}
+
+	s.additions = finalAdditions
+	return &s
+}
+
+// CountOf returns statement counting rows of the select statement without ORDER BY and LIMIT,
+// the statement will be wrapped as sub query when with modifiers like DISTINCT, GROUP BY or combination.
+func CountOf(s *StmtSelect) SqlExpr {
+	stmt := s.WithoutAdditions(AdditionOrderBy, AdditionLimit)
+
+	if len(stmt.modifiers) > 0 || IsNilExpr(stmt.table) || hasAdditionTypeOf(stmt.additions, AdditionGroupBy, AdditionCombination) {
+		return &StmtSelect{
+			sqlExpr:     Expr("COUNT(1) FROM ? AS t_count", SubQuery(stmt)),
+			primaryOnly: stmt.IsPrimaryOnly(),
+		}
+	}
+
+	return Select(Count()).From(stmt.table, stmt.additions...)
+}
+
+func hasAdditionTypeOf(additions []Addition, additionTypes ...AdditionType) bool {
+	for i := range additions {
+		if !IsNilExpr(additions[i]) && isAdditionTypeOf(additions[i], additionTypes...) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAdditionTypeOf(addition Addition, additionTypes ...AdditionType) bool {
+	for _, t := range additionTypes {
+		if addition.AdditionType() == t {
+			return true
+		}
+	}
+	return false
 }
@@ -69,3 +69,62 @@ FOR UPDATE
 		))
 	})
 }
+
+func TestStmtSelectForPage(t *testing.T) {
+	table := T("T")
+
+	stmt := Select(nil).
+		From(
+			table,
+			Where(Col("F_a").Eq(1)),
+			OrderBy(AscOrder(Col("F_b"))),
+			Limit(10),
+		)
+
+	t.Run("orders", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(stmt.Orders()).To(gomega.HaveLen(1))
+	})
+
+	t.Run("count", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(CountOf(stmt)).To(BeExpr(`
+SELECT COUNT(1) FROM T
+WHERE f_a = ?
+`, 1))
+	})
+
+	t.Run("count distinct", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			CountOf(Select(Col("F_a"), "DISTINCT").From(table, Where(Col("F_a").Gt(1)))),
+		).To(BeExpr(`
+SELECT COUNT(1) FROM (SELECT DISTINCT f_a FROM T
+WHERE f_a > ?) AS t_count
+`, 1))
+	})
+
+	t.Run("with additions", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			stmt.WithoutAdditions(AdditionLimit).WithAdditions(Where(Col("F_b").Gt(2)), Limit(5)),
+		).To(BeExpr(`
+SELECT * FROM T
+WHERE (f_a = ?) AND (f_b > ?)
+ORDER BY (f_b) ASC
+LIMIT 5
+`, 1, 2))
+	})
+}
+
+func TestStmtSelectPrimaryOnly(t *testing.T) {
+	table := T("T", Col("F_a"))
+
+	gomega.NewWithT(t).Expect(Select(nil).From(table, Where(Col("F_a").Eq(1))).IsPrimaryOnly()).To(gomega.BeFalse())
+	gomega.NewWithT(t).Expect(Select(nil).From(table, ForUpdate()).IsPrimaryOnly()).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(Select(nil).From(table, AsAddition(Expr("FOR UPDATE"))).IsPrimaryOnly()).To(gomega.BeFalse())
+	gomega.NewWithT(t).Expect(Select(Expr("nextval('seq')")).PrimaryOnly().IsPrimaryOnly()).To(gomega.BeTrue())
+
+	t.Run("count of primary only", func(t *testing.T) {
+		stmt := Select(nil, "DISTINCT").From(table).PrimaryOnly()
+
+		gomega.NewWithT(t).Expect(CountOf(stmt).(*StmtSelect).IsPrimaryOnly()).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(CountOf(stmt)).To(BeExpr("SELECT COUNT(1) FROM (SELECT DISTINCT * FROM T) AS t_count"))
+	})
+}
@@ -49,6 +49,8 @@ func TableAliasFromContext(ctx context.Context) string {
 	return ""
 }
 
+// ColumnsByStruct returns columns aliased as <table>__<column> by fields of struct,
+// columns of elem of slice field for one-to-many relation will be included too.
 func ColumnsByStruct(v interface{}) *Ex {
 	ctx := context.Background()
 
@@ -57,29 +59,38 @@ func ColumnsByStruct(v interface{}) *Ex {
 	e := Expr("")
 	e.Grow(len(fields))
 
-	i := 0
+	writeColumnsByStruct(e, reflect.ValueOf(v), "")
 
-	ForEachStructFieldValue(context.Background(), reflect.ValueOf(v), func(field *StructFieldValue) {
-		if i > 0 {
+	return e
+}
+
+func writeColumnsByStruct(e *Ex, rv reflect.Value, tableAlias string) {
+	ForEachStructFieldValue(context.Background(), rv, func(field *StructFieldValue) {
+		if e.b.Len() > 0 {
 			e.WriteQuery(", ")
 		}
 
-		if field.TableName != "" {
-			e.WriteQuery(field.TableName)
+		tableName := field.TableName
+		if tableAlias != "" && field.Field.TableAlias == "" {
+			tableName = tableAlias
+		}
+
+		if tableName != "" {
+			e.WriteQuery(tableName)
 			e.WriteQueryByte('.')
 			e.WriteQuery(field.Field.Name)
 			e.WriteQuery(" AS ")
-			e.WriteQuery(field.TableName)
+			e.WriteQuery(tableName)
 			e.WriteQuery("__")
 			e.WriteQuery(field.Field.Name)
 		} else {
 			e.WriteQuery(field.Field.Name)
 		}
-
-		i++
 	})
 
-	return e
+	for _, sf := range SliceStructFieldsFor(rv.Type()) {
+		writeColumnsByStruct(e, reflect.New(sf.ElemType), sf.TableAlias)
+	}
 }
 
 func ForEachStructFieldValue(ctx context.Context, v interface{}, fn func(*StructFieldValue)) {
@@ -113,7 +124,7 @@ func ForEachStructFieldValue(ctx context.Context, v interface{}, fn func(*Struct
 		} else {
 			if len(f.ModelLoc) > 0 {
 				fpv := f.FieldModelValue(rv)
-				if fpv.IsValid() {
+				if fpv.IsValid() && !(fpv.Kind() == reflect.Ptr && fpv.IsNil()) {
 					if m, ok := fpv.Interface().(Model); ok {
 						ctx = WithTableName(m.TableName())(ctx)
 					}
@@ -132,6 +143,10 @@ func ForEachStructFieldValue(ctx context.Context, v interface{}, fn func(*Struct
 			sf.TableName = tableAlias
 		}
 
+		if f.TableAlias != "" {
+			sf.TableName = f.TableAlias
+		}
+
 		fn(sf)
 	}
 
@@ -202,6 +217,10 @@ func ScanDefToTable(table *Table, i interface{}) {
 	tpe := typesx.Deref(typesx.FromRType(reflect.TypeOf(i)))
 
 	EachStructField(context.Background(), tpe, func(f *StructField) bool {
+		// skip fields of nested struct for scanning joined rows
+		if f.TableAlias != "" {
+			return true
+		}
 		table.AddCol(&Column{
 			FieldName:  f.FieldName,
 			Name:       f.Name,
@@ -137,6 +137,12 @@ type OrgUserAll struct {
 	Org  Org  `json:"org"`
 }
 
+type UserWithOrgs struct {
+	User
+	Owner User  `alias:"t_owner"`
+	Orgs  []Org `json:"orgs"`
+}
+
 func TestColumnsByStruct(t *testing.T) {
 	t.Run("simple", func(t *testing.T) {
 		q := ColumnsByStruct(&User{}).Ex(context.Background()).Query()
@@ -152,4 +158,10 @@ func TestColumnsByStruct(t *testing.T) {
 
 		gomega.NewWithT(t).Expect(q).To(gomega.Equal("t_org_user.f_org_id AS t_org_user__f_org_id, t_org_user.f_user_id AS t_org_user__f_user_id, t_user.f_id AS t_user__f_id, t_user.f_name AS t_user__f_name, t_user.f_username AS t_user__f_username, t_org.f_id AS t_org__f_id, t_org.f_name AS t_org__f_name"))
 	})
+
+	t.Run("nested and one-to-many", func(t *testing.T) {
+		q := ColumnsByStruct(&UserWithOrgs{}).Ex(context.Background()).Query()
+
+		gomega.NewWithT(t).Expect(q).To(gomega.Equal("t_user.f_id AS t_user__f_id, t_user.f_name AS t_user__f_name, t_user.f_username AS t_user__f_username, t_owner.f_id AS t_owner__f_id, t_owner.f_name AS t_owner__f_name, t_owner.f_username AS t_owner__f_username, t_org.f_id AS t_org__f_id, t_org.f_name AS t_org__f_name"))
+	})
 }
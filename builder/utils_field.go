@@ -55,9 +55,9 @@ func EachStructField(ctx context.Context, tpe typesx.Type, each func(p *StructFi
 		panic(fmt.Errorf("model %s must be a struct", tpe.Name()))
 	}
 
-	var walk func(tpe typesx.Type, modelLoc []int, parents ...int)
+	var walk func(tpe typesx.Type, modelLoc []int, tableAlias string, parents ...int)
 
-	walk = func(tpe typesx.Type, modelLoc []int, parents ...int) {
+	walk = func(tpe typesx.Type, modelLoc []int, tableAlias string, parents ...int) {
 		if ok := tpe.Implements(typesx.FromRType(typeModel)); ok {
 			modelLoc = parents
 		}
@@ -87,7 +87,9 @@ func EachStructField(ctx context.Context, tpe typesx.Type, each func(p *StructFi
 				}
 			}
 
-			if (f.Anonymous() || f.Type().Name() == f.Name()) && (!hasDB) {
+			tagAlias, hasAlias := tags["alias"]
+
+			if (f.Anonymous() || f.Type().Name() == f.Name() || hasAlias) && (!hasDB) {
 				fieldType := f.Type()
 
 				if !fieldType.Implements(typesx.FromRType(driverValuer)) {
@@ -96,7 +98,12 @@ func EachStructField(ctx context.Context, tpe typesx.Type, each func(p *StructFi
 					}
 
 					if fieldType.Kind() == reflect.Struct {
-						walk(fieldType, modelLoc, loc...)
+						if hasAlias {
+							// nested struct, columns should be aliased as <alias>__<column>
+							walk(fieldType, modelLoc, tagAlias.Name(), loc...)
+						} else {
+							walk(fieldType, modelLoc, tableAlias, loc...)
+						}
 						continue
 					}
 				}
@@ -115,6 +122,8 @@ func EachStructField(ctx context.Context, tpe typesx.Type, each func(p *StructFi
 			p.ModelLoc = make([]int, len(modelLoc))
 			copy(p.ModelLoc, modelLoc)
 
+			p.TableAlias = tableAlias
+
 			p.ColumnType = *ColumnTypeFromTypeAndTag(p.Type, string(tagDB))
 
 			if !each(p) {
@@ -123,17 +132,19 @@ func EachStructField(ctx context.Context, tpe typesx.Type, each func(p *StructFi
 		}
 	}
 
-	walk(tpe, []int{})
+	walk(tpe, []int{}, "")
 }
 
 type StructField struct {
-	Name       string
-	FieldName  string
-	Type       typesx.Type
-	Field      typesx.StructField
-	Tags       map[string]reflectx.StructTag
-	Loc        []int
-	ModelLoc   []int
+	Name      string
+	FieldName string
+	Type      typesx.Type
+	Field     typesx.StructField
+	Tags      map[string]reflectx.StructTag
+	Loc       []int
+	ModelLoc  []int
+	// TableAlias of nested struct field tagged with alias
+	TableAlias string
 	ColumnType ColumnType
 }
 
@@ -178,3 +189,98 @@ func (p *StructField) FieldValue(structReflectValue reflect.Value) reflect.Value
 func (p *StructField) FieldModelValue(structReflectValue reflect.Value) reflect.Value {
 	return fieldValue(structReflectValue, p.ModelLoc)
 }
+
+// SliceStructField field of slice of struct for one-to-many relation, like `Orgs []Org`,
+// columns of elem should be aliased as <TableAlias>__<column>,
+// TableAlias is the name of tag alias, or table name of elem when elem is Model.
+type SliceStructField struct {
+	FieldName  string
+	Loc        []int
+	ElemType   reflect.Type
+	IsPtrElem  bool
+	TableAlias string
+}
+
+func (p *SliceStructField) FieldValue(structReflectValue reflect.Value) reflect.Value {
+	return fieldValue(structReflectValue, p.Loc)
+}
+
+var sliceStructFieldsCache = sync.Map{}
+
+// SliceStructFieldsFor returns fields of slice of struct in struct type and its embedded structs
+func SliceStructFieldsFor(tpe reflect.Type) []*SliceStructField {
+	tpe = reflectx.Deref(tpe)
+
+	if v, ok := sliceStructFieldsCache.Load(tpe); ok {
+		return v.([]*SliceStructField)
+	}
+
+	fields := make([]*SliceStructField, 0)
+
+	if tpe.Kind() != reflect.Struct {
+		return fields
+	}
+
+	var walk func(tpe reflect.Type, parents ...int)
+
+	walk = func(tpe reflect.Type, parents ...int) {
+		for i := 0; i < tpe.NumField(); i++ {
+			f := tpe.Field(i)
+
+			if !ast.IsExported(f.Name) {
+				continue
+			}
+
+			tags := reflectx.ParseStructTags(string(f.Tag))
+
+			if _, hasDB := tags["db"]; hasDB {
+				continue
+			}
+
+			loc := append(append(make([]int, 0, len(parents)+1), parents...), i)
+
+			fieldType := reflectx.Deref(f.Type)
+
+			if f.Anonymous && fieldType.Kind() == reflect.Struct && !f.Type.Implements(driverValuer) {
+				walk(fieldType, loc...)
+				continue
+			}
+
+			if f.Type.Kind() != reflect.Slice {
+				continue
+			}
+
+			elemType := reflectx.Deref(f.Type.Elem())
+
+			if elemType.Kind() != reflect.Struct || reflect.PtrTo(elemType).Implements(driverValuer) {
+				continue
+			}
+
+			tableAlias := ""
+
+			if tagAlias, ok := tags["alias"]; ok {
+				tableAlias = tagAlias.Name()
+			} else if m, ok := reflect.New(elemType).Interface().(Model); ok {
+				tableAlias = m.TableName()
+			}
+
+			if tableAlias == "" {
+				continue
+			}
+
+			fields = append(fields, &SliceStructField{
+				FieldName:  f.Name,
+				Loc:        loc,
+				ElemType:   elemType,
+				IsPtrElem:  f.Type.Elem().Kind() == reflect.Ptr,
+				TableAlias: tableAlias,
+			})
+		}
+	}
+
+	walk(tpe)
+
+	sliceStructFieldsCache.Store(tpe, fields)
+
+	return fields
+}
@@ -0,0 +1,142 @@
+package sqlx
+
+import (
+	"database/sql"
+	"reflect"
+
+	reflectx "github.com/go-courier/x/reflect"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+)
+
+// BulkInsertDialect dialect which supports loading rows in bulk, like COPY of PostgreSQL
+type BulkInsertDialect interface {
+	// BulkInsert load values of each row from next into table, next returns nil values when drained
+	BulkInsert(db DBExecutor, table *builder.Table, columns *builder.Columns, next func() ([]interface{}, error)) (int64, error)
+}
+
+// BulkRowIterator iterator of models for BulkInsert
+type BulkRowIterator interface {
+	// Next returns next model, nil when drained
+	Next() (builder.Model, error)
+}
+
+// BulkRowsOf create BulkRowIterator from slice of models, like []User or []*User
+func BulkRowsOf(list interface{}) BulkRowIterator {
+	return &sliceBulkRows{rv: reflectx.Indirect(reflect.ValueOf(list))}
+}
+
+type sliceBulkRows struct {
+	rv  reflect.Value
+	idx int
+}
+
+func (s *sliceBulkRows) Next() (builder.Model, error) {
+	if s.idx >= s.rv.Len() {
+		return nil, nil
+	}
+	item := s.rv.Index(s.idx)
+	s.idx++
+	if item.Kind() != reflect.Ptr {
+		item = item.Addr()
+	}
+	return item.Interface().(builder.Model), nil
+}
+
+// BulkInsertBatchSize count of rows in each insert statement when dialect not support bulk loading
+var BulkInsertBatchSize = 500
+
+// BulkInsert insert all rows into table,
+// values of all fields except autoincrement one will be inserted.
+// rows will be loaded by BulkInsertDialect when supported,
+// otherwise be inserted by multi-row inserts with BulkInsertBatchSize rows,
+// and wrap it in tasks when all rows should be inserted atomically.
+func BulkInsert(db DBExecutor, table *builder.Table, rows BulkRowIterator) (int64, error) {
+	fieldNames := make([]string, 0, table.Columns.Len())
+
+	autoIncrementCol := table.AutoIncrement()
+
+	table.Columns.Range(func(col *builder.Column, idx int) {
+		if col.FieldName == "" || col.DeprecatedActions != nil {
+			return
+		}
+		if autoIncrementCol != nil && autoIncrementCol.FieldName == col.FieldName {
+			return
+		}
+		fieldNames = append(fieldNames, col.FieldName)
+	})
+
+	first, err := rows.Next()
+	if err != nil || first == nil {
+		return 0, err
+	}
+
+	columns, values := table.ColumnsAndValuesByFieldValues(builder.FieldValuesFromStructBy(first, fieldNames))
+
+	next := func() ([]interface{}, error) {
+		if values != nil {
+			v := values
+			values = nil
+			return v, nil
+		}
+
+		model, err := rows.Next()
+		if err != nil || model == nil {
+			return nil, err
+		}
+
+		_, v := table.ColumnsAndValuesByFieldValues(builder.FieldValuesFromStructBy(model, fieldNames))
+		return v, nil
+	}
+
+	if bulkInsertDialect, ok := db.Dialect().(BulkInsertDialect); ok {
+		return bulkInsertDialect.BulkInsert(db, table, columns, next)
+	}
+
+	return bulkInsertByBatch(db, table, columns, next, BulkInsertBatchSize)
+}
+
+func bulkInsertByBatch(db DBExecutor, table *builder.Table, columns *builder.Columns, next func() ([]interface{}, error), batchSize int) (int64, error) {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	total := int64(0)
+
+	for {
+		batch := make([]interface{}, 0, batchSize*columns.Len())
+
+		for i := 0; i < batchSize; i++ {
+			values, err := next()
+			if err != nil {
+				return total, err
+			}
+			if values == nil {
+				break
+			}
+			batch = append(batch, values...)
+		}
+
+		if len(batch) == 0 {
+			return total, nil
+		}
+
+		result, err := db.ExecExpr(builder.Insert().Into(table).Values(columns, batch...))
+		if err != nil {
+			return total, err
+		}
+
+		total += rowsAffected(result)
+
+		if len(batch) < batchSize*columns.Len() {
+			return total, nil
+		}
+	}
+}
+
+func rowsAffected(result sql.Result) int64 {
+	if result == nil {
+		return 0
+	}
+	n, _ := result.RowsAffected()
+	return n
+}
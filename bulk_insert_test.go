@@ -0,0 +1,28 @@
+package sqlx_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/onsi/gomega"
+)
+
+func TestBulkInsertByBatch(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_bulk_insert", &Member{})
+
+	members := make([]Member, 0)
+	for i := 0; i < 1200; i++ {
+		members = append(members, Member{Name: "a" + strconv.Itoa(i), Age: int32(i % 100)})
+	}
+
+	n, err := sqlx.BulkInsert(db, db.T(&Member{}), sqlx.BulkRowsOf(members))
+	NewWithT(t).Expect(err).To(BeNil())
+	NewWithT(t).Expect(n).To(Equal(int64(1200)))
+
+	count := 0
+	err = db.QueryExprAndScan(builder.Select(builder.Count()).From(db.T(&Member{})), &count)
+	NewWithT(t).Expect(err).To(BeNil())
+	NewWithT(t).Expect(count).To(Equal(1200))
+}
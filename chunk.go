@@ -0,0 +1,77 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/scanner"
+)
+
+// scanInChunks query each chunk and scan all rows into v
+func scanInChunks(ctx context.Context, exprs []*builder.Ex, queryExpr func(expr builder.SqlExpr) (*sql.Rows, error), v interface{}) error {
+	if len(exprs) == 1 {
+		rows, err := queryExpr(exprs[0])
+		if err != nil {
+			return err
+		}
+		return ScanContext(ctx, rows, v)
+	}
+
+	si, err := scanner.ScanIteratorFor(v)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range exprs {
+		rows, err := queryExpr(e)
+		if err != nil {
+			return err
+		}
+		// hide MustHasRecord to check after all chunks scanned
+		if err := ScanContext(ctx, rows, &chunkScanIterator{ScanIterator: si}); err != nil {
+			return err
+		}
+	}
+
+	if mustHasRecord, ok := si.(interface{ MustHasRecord() bool }); ok {
+		if !mustHasRecord.MustHasRecord() {
+			return NewSqlError(SqlErrTypeNotFound, "record is not found")
+		}
+	}
+
+	return nil
+}
+
+type chunkScanIterator struct {
+	ScanIterator
+}
+
+type chunkedResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r *chunkedResult) add(result sql.Result) error {
+	if result == nil {
+		return nil
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	r.rowsAffected += rowsAffected
+	// ignore the error for drivers which not support LastInsertId
+	if lastInsertID, err := result.LastInsertId(); err == nil {
+		r.lastInsertID = lastInsertID
+	}
+	return nil
+}
+
+func (r *chunkedResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r *chunkedResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
@@ -0,0 +1,67 @@
+package sqlx_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/onsi/gomega"
+)
+
+func TestChunks(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_chunks", &Member{})
+
+	table := db.T(&Member{})
+
+	n := 40000
+
+	values := make([]interface{}, 0, n*2)
+	names := make([]interface{}, 0, n)
+
+	for i := 0; i < n; i++ {
+		name := "a" + strconv.Itoa(i)
+		values = append(values, name, int32(i%100))
+		names = append(names, name)
+	}
+
+	result, err := db.ExecExpr(builder.Insert().Into(table).Values(table.MustFields("Name", "Age"), values...))
+	NewWithT(t).Expect(err).To(BeNil())
+
+	rowsAffected, _ := result.RowsAffected()
+	NewWithT(t).Expect(rowsAffected).To(Equal(int64(n)))
+
+	t.Run("select in chunks", func(t *testing.T) {
+		list := make([]Member, 0)
+		err := db.QueryExprAndScan(builder.Select(nil).From(table, builder.Where(table.F("Name").In(names...))), &list)
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(list).To(HaveLen(n))
+	})
+
+	t.Run("count could not be split", func(t *testing.T) {
+		count := 0
+		err := db.QueryExprAndScan(builder.Select(builder.Count()).From(table, builder.Where(table.F("Name").In(names...))), &count)
+		NewWithT(t).Expect(err).NotTo(BeNil())
+	})
+
+	t.Run("limit could not be split", func(t *testing.T) {
+		list := make([]Member, 0)
+		err := db.QueryExprAndScan(builder.Select(nil).From(table, builder.Where(table.F("Name").In(names...)), builder.Limit(10)), &list)
+		NewWithT(t).Expect(err).NotTo(BeNil())
+	})
+
+	t.Run("update in chunks", func(t *testing.T) {
+		result, err := db.ExecExpr(builder.Update(table).Set(table.F("Age").ValueBy(1)).Where(table.F("Name").In(names...)))
+		NewWithT(t).Expect(err).To(BeNil())
+
+		rowsAffected, _ := result.RowsAffected()
+		NewWithT(t).Expect(rowsAffected).To(Equal(int64(n)))
+	})
+
+	t.Run("delete in chunks", func(t *testing.T) {
+		result, err := db.ExecExpr(builder.Delete().From(table, builder.Where(table.F("Name").In(names...))))
+		NewWithT(t).Expect(err).To(BeNil())
+
+		rowsAffected, _ := result.RowsAffected()
+		NewWithT(t).Expect(rowsAffected).To(Equal(int64(n)))
+	})
+}
@@ -0,0 +1,160 @@
+package mysql
+
+import (
+	"bufio"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+)
+
+var _ sqlx.BulkInsertDialect = (*MysqlConnector)(nil)
+
+var bulkInsertReaderID uint64
+
+// BulkInsert load rows by LOAD DATA LOCAL INFILE with registered reader,
+// local_infile of the server should be enabled.
+func (c *MysqlConnector) BulkInsert(db sqlx.DBExecutor, table *builder.Table, columns *builder.Columns, next func() ([]interface{}, error)) (int64, error) {
+	loc := time.UTC
+	if cfg, err := mysql.ParseDSN(dsn(c.Host, c.DBName, c.Extra)); err == nil && cfg.Loc != nil {
+		loc = cfg.Loc
+	}
+
+	name := fmt.Sprintf("sqlx_bulk_insert_%d", atomic.AddUint64(&bulkInsertReaderID, 1))
+
+	r, w := io.Pipe()
+
+	started := false
+	done := make(chan error, 1)
+
+	mysql.RegisterReaderHandler(name, func() io.Reader {
+		started = true
+		go func() {
+			err := writeTSV(w, next, loc)
+			_ = w.CloseWithError(err)
+			done <- err
+		}()
+		return r
+	})
+	defer mysql.DeregisterReaderHandler(name)
+
+	e := builder.Expr("LOAD DATA LOCAL INFILE 'Reader::" + name + "' INTO TABLE ")
+	e.WriteExpr(table)
+	e.WriteQuery(" CHARACTER SET utf8mb4 FIELDS TERMINATED BY '\\t' ESCAPED BY '\\\\' LINES TERMINATED BY '\\n' ")
+	e.WriteGroup(func(e *builder.Ex) {
+		e.WriteExpr(columns)
+	})
+
+	result, err := db.ExecExpr(e)
+
+	if started {
+		// make sure the writer exit when failed before reading all
+		_ = r.Close()
+		if writeErr := <-done; writeErr != nil && writeErr != io.ErrClosedPipe {
+			return 0, writeErr
+		}
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func writeTSV(w io.Writer, next func() ([]interface{}, error), loc *time.Location) error {
+	bw := bufio.NewWriter(w)
+
+	buf := make([]byte, 0, 1024)
+
+	for {
+		values, err := next()
+		if err != nil {
+			return err
+		}
+		if values == nil {
+			break
+		}
+
+		buf = buf[:0]
+
+		for i := range values {
+			if i > 0 {
+				buf = append(buf, '\t')
+			}
+			buf, err = appendTSVValue(buf, values[i], loc)
+			if err != nil {
+				return err
+			}
+		}
+
+		buf = append(buf, '\n')
+
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+func appendTSVValue(buf []byte, value interface{}, loc *time.Location) ([]byte, error) {
+	v, err := driver.DefaultParameterConverter.ConvertValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := v.(type) {
+	case nil:
+		return append(buf, '\\', 'N'), nil
+	case int64:
+		return strconv.AppendInt(buf, x, 10), nil
+	case float64:
+		return strconv.AppendFloat(buf, x, 'g', -1, 64), nil
+	case bool:
+		if x {
+			return append(buf, '1'), nil
+		}
+		return append(buf, '0'), nil
+	case time.Time:
+		if x.IsZero() {
+			return append(buf, "0000-00-00"...), nil
+		}
+		return x.In(loc).AppendFormat(buf, "2006-01-02 15:04:05.999999"), nil
+	case []byte:
+		if x == nil {
+			return append(buf, '\\', 'N'), nil
+		}
+		return escapeTSV(buf, x), nil
+	case string:
+		return escapeTSV(buf, []byte(x)), nil
+	}
+
+	return nil, fmt.Errorf("unsupported value %T for bulk insert", v)
+}
+
+func escapeTSV(buf []byte, v []byte) []byte {
+	for _, c := range v {
+		switch c {
+		case '\\':
+			buf = append(buf, '\\', '\\')
+		case '\t':
+			buf = append(buf, '\\', 't')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case 0:
+			buf = append(buf, '\\', '0')
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,31 @@
+package mysql
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestWriteTSV(t *testing.T) {
+	rows := [][]interface{}{
+		{int64(1), "a\tb\nc\\d", nil, true},
+		{2.5, []byte("x"), time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	next := func() ([]interface{}, error) {
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+
+	b := bytes.NewBuffer(nil)
+
+	err := writeTSV(b, next, time.UTC)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(b.String()).To(gomega.Equal("1\ta\\tb\\nc\\\\d\t\\N\t1\n2.5\tx\t2020-01-01 00:00:00\t0\n"))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/connectors/stmtcache"
 
 	"github.com/go-courier/logr"
 	"github.com/pkg/errors"
@@ -29,7 +30,9 @@ var _ interface {
 } = (*MySqlLoggingDriver)(nil)
 
 type MySqlLoggingDriver struct {
-	driver mysql.MySQLDriver
+	driver         mysql.MySQLDriver
+	stmtCacheSize  int
+	stmtCacheStats *stmtcache.Stats
 }
 
 func (d *MySqlLoggingDriver) Open(dsn string) (driver.Conn, error) {
@@ -43,7 +46,11 @@ func (d *MySqlLoggingDriver) Open(dsn string) (driver.Conn, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to open connection: %s", cfg.FormatDSN())
 	}
-	return &loggerConn{Conn: conn, cfg: cfg}, nil
+	c := &loggerConn{Conn: conn, cfg: cfg}
+	if d.stmtCacheSize > 0 {
+		c.stmtCache = stmtcache.New(d.stmtCacheSize, d.stmtCacheStats)
+	}
+	return c, nil
 }
 
 func (d *MySqlLoggingDriver) Driver() driver.Driver {
@@ -52,6 +59,7 @@ func (d *MySqlLoggingDriver) Driver() driver.Driver {
 
 var _ interface {
 	driver.ConnBeginTx
+	driver.ConnPrepareContext
 	driver.ExecerContext
 	driver.QueryerContext
 } = (*loggerConn)(nil)
@@ -59,6 +67,7 @@ var _ interface {
 type loggerConn struct {
 	cfg *mysql.Config
 	driver.Conn
+	stmtCache *stmtcache.Cache
 }
 
 func (c *loggerConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
@@ -73,11 +82,61 @@ func (c *loggerConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver
 	return &loggingTx{Tx: tx, logger: logger}, nil
 }
 
+func (c *loggerConn) Close() error {
+	if c.stmtCache != nil {
+		_ = c.stmtCache.Close()
+	}
+	return c.Conn.Close()
+}
+
 func (c *loggerConn) Prepare(query string) (driver.Stmt, error) {
-	panic(fmt.Errorf("don't use Prepare"))
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *loggerConn) PrepareContext(ctx context.Context, query string) (stmt driver.Stmt, err error) {
+	cost := startTimer()
+	newCtx, logger := logr.Start(ctx, "Prepare")
+
+	defer func() {
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "prepare failed: %s", query))
+		} else {
+			logger.WithValues("cost", cost().String()).Debug(query)
+		}
+
+		logger.End()
+	}()
+
+	stmt, err = stmtcache.Prepare(newCtx, c.Conn, query)
+	if err != nil {
+		return nil, err
+	}
+	return &loggingStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+func (c *loggerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.queryContext(ctx, query, args, func(ctx context.Context) (driver.Rows, error) {
+		if c.stmtCache != nil && stmtcache.IsCacheable(query) {
+			return c.stmtCache.Query(ctx, query, args, c.prepare)
+		}
+		return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	})
+}
+
+func (c *loggerConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.execContext(ctx, query, args, func(ctx context.Context) (driver.Result, error) {
+		if c.stmtCache != nil && stmtcache.IsCacheable(query) {
+			return c.stmtCache.Exec(ctx, query, args, c.prepare)
+		}
+		return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	})
 }
 
-func (c *loggerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (rows driver.Rows, err error) {
+func (c *loggerConn) prepare(ctx context.Context, query string) (driver.Stmt, error) {
+	return stmtcache.Prepare(ctx, c.Conn, query)
+}
+
+func (c *loggerConn) queryContext(ctx context.Context, query string, args []driver.NamedValue, do func(ctx context.Context) (driver.Rows, error)) (rows driver.Rows, err error) {
 	cost := startTimer()
 	newCtx, logger := logr.Start(ctx, "Query")
 
@@ -97,11 +156,11 @@ func (c *loggerConn) QueryContext(ctx context.Context, query string, args []driv
 		logger.End()
 	}()
 
-	rows, err = c.Conn.(driver.QueryerContext).QueryContext(newCtx, query, args)
+	rows, err = do(newCtx)
 	return
 }
 
-func (c *loggerConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (result driver.Result, err error) {
+func (c *loggerConn) execContext(ctx context.Context, query string, args []driver.NamedValue, do func(ctx context.Context) (driver.Result, error)) (result driver.Result, err error) {
 	cost := startTimer()
 	newCtx, logger := logr.Start(ctx, "Query")
 
@@ -123,10 +182,41 @@ func (c *loggerConn) ExecContext(ctx context.Context, query string, args []drive
 		logger.End()
 	}()
 
-	result, err = c.Conn.(driver.ExecerContext).ExecContext(newCtx, query, args)
+	result, err = do(newCtx)
 	return
 }
 
+var _ interface {
+	driver.StmtExecContext
+	driver.StmtQueryContext
+} = (*loggingStmt)(nil)
+
+type loggingStmt struct {
+	driver.Stmt
+	conn  *loggerConn
+	query string
+}
+
+func (s *loggingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), stmtcache.NamedValues(args))
+}
+
+func (s *loggingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), stmtcache.NamedValues(args))
+}
+
+func (s *loggingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.execContext(ctx, s.query, args, func(ctx context.Context) (driver.Result, error) {
+		return stmtcache.Exec(ctx, s.Stmt, args)
+	})
+}
+
+func (s *loggingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.queryContext(ctx, s.query, args, func(ctx context.Context) (driver.Rows, error) {
+		return stmtcache.Query(ctx, s.Stmt, args)
+	})
+}
+
 func (c *loggerConn) interpolateParams(query string, args []driver.NamedValue) fmt.Stringer {
 	return &SqlPrinter{query, args, c.cfg}
 }
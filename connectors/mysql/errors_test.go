@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func TestClassifyError(t *testing.T) {
+	c := &MysqlConnector{}
+
+	cases := map[uint16]sqlx.SqlErrType{
+		1062: sqlx.SqlErrTypeConflict,
+		1452: sqlx.SqlErrTypeForeignKeyViolation,
+		1048: sqlx.SqlErrTypeNotNullViolation,
+		3819: sqlx.SqlErrTypeCheckViolation,
+		1406: sqlx.SqlErrTypeValueTooLong,
+		1213: sqlx.SqlErrTypeDeadlock,
+		1205: sqlx.SqlErrTypeLockTimeout,
+		1317: sqlx.SqlErrTypeCanceled,
+		2013: sqlx.SqlErrTypeConnectionLost,
+		1064: "",
+	}
+
+	for number, tpe := range cases {
+		err := errors.Wrap(&mysql.MySQLError{Number: number}, "exec failed")
+		gomega.NewWithT(t).Expect(c.ClassifyError(err)).To(gomega.Equal(tpe))
+	}
+
+	gomega.NewWithT(t).Expect(c.ClassifyError(mysql.ErrInvalidConn)).To(gomega.Equal(sqlx.SqlErrTypeConnectionLost))
+}
+
+func TestConflictKey(t *testing.T) {
+	c := &MysqlConnector{}
+
+	tables := &builder.Tables{}
+
+	table := builder.T("t_user",
+		builder.Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+		builder.Col("f_name").Field("Name").Type("", ",size=128"),
+		builder.Col("f_org_id").Field("OrgID").Type(uint64(0), ""),
+	)
+	table.AddKey(&builder.Key{Name: "primary", IsUnique: true, Def: *builder.ParseIndexDef("ID")})
+	table.AddKey(&builder.Key{Name: "i_name", IsUnique: true, Def: *builder.ParseIndexDef("OrgID", "Name")})
+	tables.Add(table)
+
+	cases := map[string][]string{
+		"Duplicate entry '1-a' for key 'i_name'":        {"OrgID", "Name"},
+		"Duplicate entry '1-a' for key 't_user.i_name'": {"OrgID", "Name"},
+		"Duplicate entry '1' for key 'PRIMARY'":         {"ID"},
+		"Duplicate entry '1' for key 't_user.PRIMARY'":  {"ID"},
+	}
+
+	for msg, fieldNames := range cases {
+		key := c.ConflictKey(errors.Wrap(&mysql.MySQLError{Number: 1062, Message: msg}, "exec failed"), tables)
+		gomega.NewWithT(t).Expect(key).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(key.FieldNames()).To(gomega.Equal(fieldNames))
+	}
+
+	gomega.NewWithT(t).Expect(c.ConflictKey(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'i_other'"}, tables)).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(c.ConflictKey(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 't_other.i_name'"}, tables)).To(gomega.BeNil())
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/go-sql-driver/mysql"
 	"github.com/kunlun-qilian/sqlx/v3"
 	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/connectors/stmtcache"
 	"github.com/kunlun-qilian/sqlx/v3/migration"
 )
 
@@ -29,6 +32,11 @@ type MysqlConnector struct {
 	Extra   string
 	Engine  string
 	Charset string
+	// StmtCacheSize max count of prepared statements cached for each connection,
+	// statement cache disabled when zero
+	StmtCacheSize int
+
+	stmtCacheStats *stmtcache.Stats
 }
 
 func dsn(host string, dbName string, extra string) string {
@@ -40,9 +48,17 @@ func dsn(host string, dbName string, extra string) string {
 
 func (c MysqlConnector) WithDBName(dbName string) driver.Connector {
 	c.DBName = dbName
+	if c.StmtCacheSize > 0 {
+		c.stmtCacheStats = &stmtcache.Stats{}
+	}
 	return &c
 }
 
+// StmtCacheStats returns hit and miss stats of statement caches of all connections
+func (c *MysqlConnector) StmtCacheStats() *stmtcache.Stats {
+	return c.stmtCacheStats
+}
+
 func (c *MysqlConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) error {
 	output := migration.MigrationOutputFromContext(ctx)
 
@@ -129,7 +145,15 @@ func (c *MysqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
 }
 
 func (c MysqlConnector) Driver() driver.Driver {
-	return (&MySqlLoggingDriver{}).Driver()
+	return (&MySqlLoggingDriver{
+		stmtCacheSize:  c.StmtCacheSize,
+		stmtCacheStats: c.stmtCacheStats,
+	}).Driver()
+}
+
+// MaxParams placeholders of prepared statement is limited to 65535
+func (MysqlConnector) MaxParams() int {
+	return 65535
 }
 
 func (MysqlConnector) DriverName() string {
@@ -141,19 +165,110 @@ func (MysqlConnector) PrimaryKeyName() string {
 }
 
 func (c MysqlConnector) IsErrorUnknownDatabase(err error) bool {
-	if mysqlErr, ok := sqlx.UnwrapAll(err).(*mysql.MySQLError); ok && mysqlErr.Number == 1049 {
+	mysqlErr := &mysql.MySQLError{}
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1049 {
 		return true
 	}
 	return false
 }
 
 func (c MysqlConnector) IsErrorConflict(err error) bool {
-	if mysqlErr, ok := sqlx.UnwrapAll(err).(*mysql.MySQLError); ok && mysqlErr.Number == 1062 {
+	mysqlErr := &mysql.MySQLError{}
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
 		return true
 	}
 	return false
 }
 
+// IsErrorRetryable deadlock found when trying to get lock
+func (c MysqlConnector) IsErrorRetryable(err error) bool {
+	mysqlErr := &mysql.MySQLError{}
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1213 {
+		return true
+	}
+	return false
+}
+
+// ClassifyError classify error by number of mysql error
+func (c MysqlConnector) ClassifyError(err error) sqlx.SqlErrType {
+	mysqlErr := &mysql.MySQLError{}
+
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1062:
+			return sqlx.SqlErrTypeConflict
+		case 1216, 1217, 1451, 1452:
+			return sqlx.SqlErrTypeForeignKeyViolation
+		case 1048, 1364:
+			return sqlx.SqlErrTypeNotNullViolation
+		case 3819:
+			return sqlx.SqlErrTypeCheckViolation
+		case 1406:
+			return sqlx.SqlErrTypeValueTooLong
+		case 1213:
+			return sqlx.SqlErrTypeDeadlock
+		case 1205:
+			return sqlx.SqlErrTypeLockTimeout
+		case 1317, 3024:
+			return sqlx.SqlErrTypeCanceled
+		case 2006, 2013:
+			return sqlx.SqlErrTypeConnectionLost
+		}
+		return ""
+	}
+
+	if errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, driver.ErrBadConn) {
+		return sqlx.SqlErrTypeConnectionLost
+	}
+
+	return ""
+}
+
+var duplicateEntryKeyRegexp = regexp.MustCompile(`for key '([^']+)'`)
+
+// ConflictKey resolve key by name in message of duplicate entry error,
+// like "Duplicate entry 'x' for key 'i_name'" or "... for key 't_user.i_name'" since mysql 8.0.19
+func (MysqlConnector) ConflictKey(err error, tables *builder.Tables) *builder.Key {
+	mysqlErr := &mysql.MySQLError{}
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != 1062 {
+		return nil
+	}
+
+	matched := duplicateEntryKeyRegexp.FindStringSubmatch(mysqlErr.Message)
+	if matched == nil {
+		return nil
+	}
+
+	tableName, keyName := "", matched[1]
+	if i := strings.LastIndex(keyName, "."); i > 0 {
+		tableName, keyName = keyName[0:i], keyName[i+1:]
+	}
+
+	if tableName != "" {
+		if table := tables.Table(tableName); table != nil {
+			return table.Keys.Key(keyName)
+		}
+		return nil
+	}
+
+	// without table name, key should be unique in all tables
+	var found *builder.Key
+	count := 0
+
+	tables.Range(func(table *builder.Table, idx int) {
+		if key := table.Keys.Key(keyName); key != nil && key.IsUnique {
+			found = key
+			count++
+		}
+	})
+
+	if count != 1 {
+		return nil
+	}
+
+	return found
+}
+
 func quoteString(name string) string {
 	if len(name) < 2 ||
 		(name[0] == '`' && name[len(name)-1] == '`') {
@@ -184,6 +299,27 @@ func (c *MysqlConnector) DropDatabase(dbName string) builder.SqlExpr {
 	return e
 }
 
+func (c *MysqlConnector) Savepoint(name string) builder.SqlExpr {
+	e := builder.Expr("SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *MysqlConnector) RollbackToSavepoint(name string) builder.SqlExpr {
+	e := builder.Expr("ROLLBACK TO SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *MysqlConnector) ReleaseSavepoint(name string) builder.SqlExpr {
+	e := builder.Expr("RELEASE SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
 func (c *MysqlConnector) AddIndex(key *builder.Key) builder.SqlExpr {
 	if key.IsPrimary() {
 		e := builder.Expr("ALTER TABLE ")
@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/lib/pq"
+)
+
+var _ sqlx.BulkInsertDialect = (*PostgreSQLConnector)(nil)
+
+// BulkInsert load rows by COPY ... FROM STDIN in transaction
+func (c *PostgreSQLConnector) BulkInsert(db sqlx.DBExecutor, table *builder.Table, columns *builder.Columns, next func() ([]interface{}, error)) (int64, error) {
+	columnNames := make([]string, 0, columns.Len())
+	columns.Range(func(col *builder.Column, idx int) {
+		columnNames = append(columnNames, col.Name)
+	})
+
+	total := int64(0)
+
+	err := sqlx.NewTasks(db).With(func(db sqlx.DBExecutor) error {
+		preparer, ok := db.(interface {
+			Prepare(query string) (*sql.Stmt, error)
+		})
+		if !ok {
+			return fmt.Errorf("%T could not prepare statement for COPY", db)
+		}
+
+		query := pq.CopyIn(table.Name, columnNames...)
+		if table.Schema != "" {
+			query = pq.CopyInSchema(table.Schema, table.Name, columnNames...)
+		}
+
+		stmt, err := preparer.Prepare(query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for {
+			values, err := next()
+			if err != nil {
+				return err
+			}
+			if values == nil {
+				break
+			}
+			if _, err := stmt.Exec(values...); err != nil {
+				return err
+			}
+			total++
+		}
+
+		// flush buffered rows
+		_, err = stmt.Exec()
+		return err
+	}).Do()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,128 @@
+package postgresql
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/scanner"
+)
+
+// DeclareCursor declare server-side cursor for the select statement,
+// db must be in transaction, and the cursor will be closed when transaction end.
+// name must be unquoted identifier, like c_user.
+func DeclareCursor(db sqlx.DBExecutor, name string, stmt *builder.StmtSelect) (*Cursor, error) {
+	if maybeTx, ok := db.(sqlx.MaybeTxExecutor); !ok || !maybeTx.IsTx() {
+		return nil, sqlx.ErrNotTx
+	}
+
+	if !isValidCursorName(name) {
+		return nil, fmt.Errorf("invalid cursor name %q, should be identifier like c_user", name)
+	}
+
+	e := builder.Expr("DECLARE ")
+	e.WriteQuery(name)
+	e.WriteQuery(" NO SCROLL CURSOR FOR ")
+	e.WriteExpr(stmt)
+
+	if _, err := db.ExecExpr(e); err != nil {
+		return nil, err
+	}
+
+	return &Cursor{db: db, name: name}, nil
+}
+
+var reCursorName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isValidCursorName cursor name will be written into DECLARE, FETCH and CLOSE as is,
+// so only unquoted identifier in max length of postgres is allowed.
+func isValidCursorName(name string) bool {
+	return len(name) <= 63 && reCursorName.MatchString(name)
+}
+
+type Cursor struct {
+	db     sqlx.DBExecutor
+	name   string
+	closed bool
+}
+
+func (c *Cursor) Name() string {
+	return c.name
+}
+
+// Fetch fetch next n rows into v, which could be slice or sqlx.ScanIterator,
+// and returns count of fetched rows, less than n means the cursor is drained.
+func (c *Cursor) Fetch(n int, v interface{}) (int, error) {
+	if c.closed {
+		return 0, fmt.Errorf("cursor %s is closed", c.name)
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("fetch size must be greater than 0, but got %d", n)
+	}
+
+	si, err := scanner.ScanIteratorFor(v)
+	if err != nil {
+		return 0, err
+	}
+
+	counter := &countScanIterator{ScanIterator: si}
+
+	rows, err := c.db.QueryExpr(builder.Expr(fmt.Sprintf("FETCH %d FROM %s", n, c.name)))
+	if err != nil {
+		return 0, err
+	}
+
+	if err := scanner.Scan(c.db.Context(), rows, counter); err != nil {
+		return counter.count, err
+	}
+
+	return counter.count, nil
+}
+
+// Each fetch rows in batches of batchSize and call fn with each row until drained,
+// fn must be func(row T) error or func(row *T) error,
+// return sqlx.StopIteration in fn to stop fetching.
+func (c *Cursor) Each(batchSize int, fn interface{}) error {
+	si, err := scanner.ScanIteratorFromFunc(fn)
+	if err != nil {
+		return err
+	}
+
+	for {
+		n, err := c.Fetch(batchSize, si)
+		if err != nil {
+			if err == scanner.StopIteration {
+				return nil
+			}
+			return err
+		}
+		if n < batchSize {
+			return nil
+		}
+	}
+}
+
+// Close close the cursor, could be skipped when transaction will be end
+func (c *Cursor) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	e := builder.Expr("CLOSE ")
+	e.WriteQuery(c.name)
+
+	_, err := c.db.ExecExpr(e)
+	return err
+}
+
+type countScanIterator struct {
+	scanner.ScanIterator
+	count int
+}
+
+func (s *countScanIterator) Next(v interface{}) error {
+	s.count++
+	return s.ScanIterator.Next(v)
+}
@@ -0,0 +1,28 @@
+package postgresql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestIsValidCursorName(t *testing.T) {
+	cases := map[string]bool{
+		"c_user":                true,
+		"_c1":                   true,
+		"C_User":                true,
+		"":                      false,
+		"1c":                    false,
+		"c user":                false,
+		"c;DROP TABLE t_user":   false,
+		`"c_user"`:              false,
+		"c-user":                false,
+		strings.Repeat("c", 63): true,
+		strings.Repeat("c", 64): false,
+	}
+
+	for name, valid := range cases {
+		gomega.NewWithT(t).Expect(isValidCursorName(name)).To(gomega.Equal(valid), name)
+	}
+}
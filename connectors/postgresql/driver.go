@@ -1,15 +1,13 @@
 package postgresql
 
 import (
-	"bytes"
 	"context"
 	"database/sql/driver"
-	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/connectors/stmtcache"
 
 	"github.com/go-courier/logr"
 	"github.com/lib/pq"
@@ -21,7 +19,9 @@ var _ interface {
 } = (*PostgreSQLLoggingDriver)(nil)
 
 type PostgreSQLLoggingDriver struct {
-	driver pq.Driver
+	driver         pq.Driver
+	stmtCacheSize  int
+	stmtCacheStats *stmtcache.Stats
 }
 
 func (d *PostgreSQLLoggingDriver) Open(dsn string) (driver.Conn, error) {
@@ -40,11 +40,16 @@ func (d *PostgreSQLLoggingDriver) Open(dsn string) (driver.Conn, error) {
 		return nil, errors.Wrapf(err, "failed to open connection: %s", opts)
 	}
 
-	return &loggerConn{Conn: conn, cfg: opts}, nil
+	c := &loggerConn{Conn: conn, cfg: opts}
+	if d.stmtCacheSize > 0 {
+		c.stmtCache = stmtcache.New(d.stmtCacheSize, d.stmtCacheStats)
+	}
+	return c, nil
 }
 
 var _ interface {
 	driver.ConnBeginTx
+	driver.ConnPrepareContext
 	driver.ExecerContext
 	driver.QueryerContext
 } = (*loggerConn)(nil)
@@ -52,6 +57,7 @@ var _ interface {
 type loggerConn struct {
 	cfg PostgreSQLOpts
 	driver.Conn
+	stmtCache *stmtcache.Cache
 }
 
 func (c *loggerConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
@@ -67,6 +73,9 @@ func (c *loggerConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver
 }
 
 func (c *loggerConn) Close() error {
+	if c.stmtCache != nil {
+		_ = c.stmtCache.Close()
+	}
 	if err := c.Conn.Close(); err != nil {
 		return err
 	}
@@ -74,10 +83,75 @@ func (c *loggerConn) Close() error {
 }
 
 func (c *loggerConn) Prepare(query string) (driver.Stmt, error) {
-	panic(fmt.Errorf("don't use Prepare"))
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *loggerConn) PrepareContext(ctx context.Context, query string) (stmt driver.Stmt, err error) {
+	newCtx, logger := logr.Start(ctx, "Prepare")
+	cost := startTimer()
+
+	defer func() {
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "prepare failed: %s", query))
+		} else {
+			logger.WithValues("cost", cost().String()).Debug(query)
+		}
+
+		logger.End()
+	}()
+
+	stmt, err = c.prepare(newCtx, query)
+	if err != nil {
+		return nil, err
+	}
+	if isCopyIn(query) {
+		return &copyInStmt{Stmt: stmt, query: query, cost: startTimer()}, nil
+	}
+	return &loggingStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+// prepare value holders will be rewritten without args checking,
+// the count of args will be checked by the statement.
+func (c *loggerConn) prepare(ctx context.Context, query string) (driver.Stmt, error) {
+	q, err := replaceValueHolder(query, -1)
+	if err != nil {
+		return nil, err
+	}
+	return stmtcache.Prepare(ctx, c.Conn, q)
+}
+
+// prepareRewritten prepare query which value holders already rewritten, ?? should not be rewritten again
+func (c *loggerConn) prepareRewritten(ctx context.Context, q string) (driver.Stmt, error) {
+	return stmtcache.Prepare(ctx, c.Conn, q)
+}
+
+func (c *loggerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.queryContext(ctx, query, args, func(ctx context.Context) (driver.Rows, error) {
+		q, err := replaceValueHolder(query, len(args))
+		if err != nil {
+			return nil, err
+		}
+		if c.stmtCache != nil && stmtcache.IsCacheable(q) {
+			return c.stmtCache.Query(ctx, q, args, c.prepareRewritten)
+		}
+		return c.Conn.(driver.QueryerContext).QueryContext(ctx, q, args)
+	})
+}
+
+func (c *loggerConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.execContext(ctx, query, args, func(ctx context.Context) (driver.Result, error) {
+		q, err := replaceValueHolder(query, len(args))
+		if err != nil {
+			return nil, err
+		}
+		if c.stmtCache != nil && stmtcache.IsCacheable(q) {
+			return c.stmtCache.Exec(ctx, q, args, c.prepareRewritten)
+		}
+		return c.Conn.(driver.ExecerContext).ExecContext(ctx, q, args)
+	})
 }
 
-func (c *loggerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (rows driver.Rows, err error) {
+func (c *loggerConn) queryContext(ctx context.Context, query string, args []driver.NamedValue, do func(ctx context.Context) (driver.Rows, error)) (rows driver.Rows, err error) {
 	newCtx, logger := logr.Start(ctx, "Query")
 	cost := startTimer()
 
@@ -97,11 +171,11 @@ func (c *loggerConn) QueryContext(ctx context.Context, query string, args []driv
 		logger.End()
 	}()
 
-	rows, err = c.Conn.(driver.QueryerContext).QueryContext(newCtx, replaceValueHolder(query), args)
+	rows, err = do(newCtx)
 	return
 }
 
-func (c *loggerConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (result driver.Result, err error) {
+func (c *loggerConn) execContext(ctx context.Context, query string, args []driver.NamedValue, do func(ctx context.Context) (driver.Result, error)) (result driver.Result, err error) {
 	cost := startTimer()
 	newCtx, logger := logr.Start(ctx, "Exec")
 
@@ -124,29 +198,86 @@ func (c *loggerConn) ExecContext(ctx context.Context, query string, args []drive
 		logger.End()
 	}()
 
-	result, err = c.Conn.(driver.ExecerContext).ExecContext(newCtx, replaceValueHolder(query), args)
+	result, err = do(newCtx)
 	return
 }
 
-func replaceValueHolder(query string) string {
-	index := 0
-	data := []byte(query)
+var _ interface {
+	driver.StmtExecContext
+	driver.StmtQueryContext
+} = (*loggingStmt)(nil)
+
+type loggingStmt struct {
+	driver.Stmt
+	conn  *loggerConn
+	query string
+}
 
-	e := bytes.NewBufferString("")
+func (s *loggingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), stmtcache.NamedValues(args))
+}
+
+func (s *loggingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), stmtcache.NamedValues(args))
+}
+
+func (s *loggingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.execContext(ctx, s.query, args, func(ctx context.Context) (driver.Result, error) {
+		return stmtcache.Exec(ctx, s.Stmt, args)
+	})
+}
+
+func (s *loggingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.queryContext(ctx, s.query, args, func(ctx context.Context) (driver.Rows, error) {
+		return stmtcache.Query(ctx, s.Stmt, args)
+	})
+}
 
-	for i := range data {
-		c := data[i]
-		switch c {
-		case '?':
-			e.WriteByte('$')
-			e.WriteString(strconv.FormatInt(int64(index+1), 10))
-			index++
-		default:
-			e.WriteByte(c)
+func isCopyIn(query string) bool {
+	q := strings.ToUpper(strings.TrimSpace(query))
+	return strings.HasPrefix(q, "COPY ") && strings.HasSuffix(q, "FROM STDIN")
+}
+
+var _ interface {
+	driver.StmtExecContext
+} = (*copyInStmt)(nil)
+
+// copyInStmt statement of COPY ... FROM STDIN, each Exec with values buffers one row,
+// so rows will not be logged one by one, but logged once with count of rows when flushed by Exec without values.
+type copyInStmt struct {
+	driver.Stmt
+	query string
+	rows  int64
+	cost  func() time.Duration
+}
+
+func (s *copyInStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), stmtcache.NamedValues(args))
+}
+
+func (s *copyInStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if len(args) > 0 {
+		result, err := stmtcache.Exec(ctx, s.Stmt, args)
+		if err != nil {
+			logr.FromContext(ctx).Error(errors.Wrapf(err, "copy failed at row %d: %s", s.rows+1, s.query))
+			return nil, err
 		}
+		s.rows++
+		return result, nil
 	}
 
-	return e.String()
+	newCtx, logger := logr.Start(ctx, "CopyIn")
+	defer logger.End()
+
+	result, err := stmtcache.Exec(newCtx, s.Stmt, args)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "copy failed: %s", s.query))
+		return nil, err
+	}
+
+	logger.WithValues("rows", s.rows, "cost", s.cost().String()).Debug(s.query)
+
+	return result, nil
 }
 
 func startTimer() func() time.Duration {
@@ -0,0 +1,75 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/go-courier/logr"
+	"github.com/lib/pq"
+	"github.com/onsi/gomega"
+)
+
+type fakeCopyStmt struct {
+	driver.Stmt
+	rows int
+}
+
+func (s *fakeCopyStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeCopyStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if len(args) > 0 {
+		s.rows++
+	}
+	return driver.RowsAffected(0), nil
+}
+
+type recordLogger struct {
+	logr.Logger
+	logs []string
+}
+
+func (l *recordLogger) Start(ctx context.Context, name string, keyAndValues ...interface{}) (context.Context, logr.Logger) {
+	return ctx, l
+}
+
+func (l *recordLogger) End() {
+}
+
+func (l *recordLogger) WithValues(keyAndValues ...interface{}) logr.Logger {
+	l.logs = append(l.logs, fmt.Sprint(keyAndValues[0:2]...))
+	return l
+}
+
+func (l *recordLogger) Debug(msg string, args ...interface{}) {
+	l.logs = append(l.logs, fmt.Sprintf(msg, args...))
+}
+
+func TestCopyInStmt(t *testing.T) {
+	query := pq.CopyIn("t_user", "f_name", "f_age")
+
+	gomega.NewWithT(t).Expect(isCopyIn(query)).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(isCopyIn("SELECT 'COPY x FROM STDIN'")).To(gomega.BeFalse())
+
+	logger := &recordLogger{}
+	ctx := logr.WithLogger(context.Background(), logger)
+
+	fake := &fakeCopyStmt{}
+	stmt := &copyInStmt{Stmt: fake, query: query, cost: startTimer()}
+
+	for i := 0; i < 3; i++ {
+		_, err := stmt.ExecContext(ctx, []driver.NamedValue{{Ordinal: 1, Value: "a"}, {Ordinal: 2, Value: int64(i)}})
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	}
+
+	gomega.NewWithT(t).Expect(logger.logs).To(gomega.BeEmpty())
+
+	_, err := stmt.ExecContext(ctx, nil)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	gomega.NewWithT(t).Expect(fake.rows).To(gomega.Equal(3))
+	gomega.NewWithT(t).Expect(logger.logs).To(gomega.Equal([]string{"rows3", query}))
+}
@@ -0,0 +1,61 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/lib/pq"
+	"github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+func TestClassifyError(t *testing.T) {
+	c := &PostgreSQLConnector{}
+
+	cases := map[pq.ErrorCode]sqlx.SqlErrType{
+		"23505": sqlx.SqlErrTypeConflict,
+		"23503": sqlx.SqlErrTypeForeignKeyViolation,
+		"23502": sqlx.SqlErrTypeNotNullViolation,
+		"23514": sqlx.SqlErrTypeCheckViolation,
+		"22001": sqlx.SqlErrTypeValueTooLong,
+		"40P01": sqlx.SqlErrTypeDeadlock,
+		"55P03": sqlx.SqlErrTypeLockTimeout,
+		"57014": sqlx.SqlErrTypeCanceled,
+		"08006": sqlx.SqlErrTypeConnectionLost,
+		"42601": "",
+	}
+
+	for code, tpe := range cases {
+		err := errors.Wrap(&pq.Error{Code: code}, "exec failed")
+		gomega.NewWithT(t).Expect(c.ClassifyError(err)).To(gomega.Equal(tpe))
+	}
+}
+
+func TestConflictKey(t *testing.T) {
+	c := &PostgreSQLConnector{}
+
+	tables := &builder.Tables{}
+
+	table := builder.T("t_user",
+		builder.Col("f_id").Field("ID").Type(uint64(0), ",autoincrement"),
+		builder.Col("f_name").Field("Name").Type("", ",size=128"),
+		builder.Col("f_org_id").Field("OrgID").Type(uint64(0), ""),
+	)
+	table.AddKey(&builder.Key{Name: "primary", IsUnique: true, Def: *builder.ParseIndexDef("ID")})
+	table.AddKey(&builder.Key{Name: "i_name", IsUnique: true, Def: *builder.ParseIndexDef("OrgID", "Name")})
+	tables.Add(table)
+
+	t.Run("unique index", func(t *testing.T) {
+		key := c.ConflictKey(errors.Wrap(&pq.Error{Code: "23505", Table: "t_user", Constraint: "t_user_i_name"}, "exec failed"), tables)
+		gomega.NewWithT(t).Expect(key.FieldNames()).To(gomega.Equal([]string{"OrgID", "Name"}))
+	})
+	t.Run("primary key", func(t *testing.T) {
+		key := c.ConflictKey(&pq.Error{Code: "23505", Table: "t_user", Constraint: "t_user_pkey"}, tables)
+		gomega.NewWithT(t).Expect(key.FieldNames()).To(gomega.Equal([]string{"ID"}))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(c.ConflictKey(&pq.Error{Code: "23505", Table: "t_other", Constraint: "t_other_pkey"}, tables)).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(c.ConflictKey(&pq.Error{Code: "23505", Table: "t_user", Constraint: "t_user_i_other"}, tables)).To(gomega.BeNil())
+	})
+}
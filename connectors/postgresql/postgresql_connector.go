@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/kunlun-qilian/sqlx/v3"
 	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/connectors/stmtcache"
 	"github.com/kunlun-qilian/sqlx/v3/migration"
 	"github.com/lib/pq"
 )
@@ -28,6 +30,11 @@ type PostgreSQLConnector struct {
 	DBName     string
 	Extra      string
 	Extensions []string
+	// StmtCacheSize max count of prepared statements cached for each connection,
+	// statement cache disabled when zero
+	StmtCacheSize int
+
+	stmtCacheStats *stmtcache.Stats
 }
 
 func (c *PostgreSQLConnector) Connect(ctx context.Context) (driver.Conn, error) {
@@ -59,8 +66,11 @@ func (c *PostgreSQLConnector) Connect(ctx context.Context) (driver.Conn, error)
 	return conn, nil
 }
 
-func (PostgreSQLConnector) Driver() driver.Driver {
-	return &PostgreSQLLoggingDriver{}
+func (c PostgreSQLConnector) Driver() driver.Driver {
+	return &PostgreSQLLoggingDriver{
+		stmtCacheSize:  c.StmtCacheSize,
+		stmtCacheStats: c.stmtCacheStats,
+	}
 }
 
 func dsn(host string, dbName string, extra string) string {
@@ -72,9 +82,17 @@ func dsn(host string, dbName string, extra string) string {
 
 func (c PostgreSQLConnector) WithDBName(dbName string) driver.Connector {
 	c.DBName = dbName
+	if c.StmtCacheSize > 0 {
+		c.stmtCacheStats = &stmtcache.Stats{}
+	}
 	return &c
 }
 
+// StmtCacheStats returns hit and miss stats of statement caches of all connections
+func (c *PostgreSQLConnector) StmtCacheStats() *stmtcache.Stats {
+	return c.stmtCacheStats
+}
+
 func (c *PostgreSQLConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) error {
 	output := migration.MigrationOutputFromContext(ctx)
 
@@ -143,6 +161,11 @@ func (c *PostgreSQLConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) e
 	return nil
 }
 
+// MaxParams bind parameters of one statement is limited to 65535
+func (PostgreSQLConnector) MaxParams() int {
+	return 65535
+}
+
 func (PostgreSQLConnector) DriverName() string {
 	return "postgres"
 }
@@ -152,19 +175,99 @@ func (PostgreSQLConnector) PrimaryKeyName() string {
 }
 
 func (PostgreSQLConnector) IsErrorUnknownDatabase(err error) bool {
-	if e, ok := sqlx.UnwrapAll(err).(*pq.Error); ok && e.Code == "3D000" {
+	e := &pq.Error{}
+	if errors.As(err, &e) && e.Code == "3D000" {
 		return true
 	}
 	return false
 }
 
 func (PostgreSQLConnector) IsErrorConflict(err error) bool {
-	if e, ok := sqlx.UnwrapAll(err).(*pq.Error); ok && e.Code == "23505" {
+	e := &pq.Error{}
+	if errors.As(err, &e) && e.Code == "23505" {
 		return true
 	}
 	return false
 }
 
+// IsErrorRetryable serialization_failure or deadlock_detected
+func (PostgreSQLConnector) IsErrorRetryable(err error) bool {
+	e := &pq.Error{}
+	if errors.As(err, &e) && (e.Code == "40001" || e.Code == "40P01") {
+		return true
+	}
+	return false
+}
+
+// ClassifyError classify error by sql state of postgres error
+func (PostgreSQLConnector) ClassifyError(err error) sqlx.SqlErrType {
+	pgErr := &pq.Error{}
+
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return sqlx.SqlErrTypeConflict
+		case "23503":
+			return sqlx.SqlErrTypeForeignKeyViolation
+		case "23502":
+			return sqlx.SqlErrTypeNotNullViolation
+		case "23514":
+			return sqlx.SqlErrTypeCheckViolation
+		case "22001":
+			return sqlx.SqlErrTypeValueTooLong
+		case "40P01":
+			return sqlx.SqlErrTypeDeadlock
+		case "55P03":
+			return sqlx.SqlErrTypeLockTimeout
+		case "57014":
+			return sqlx.SqlErrTypeCanceled
+		case "57P01", "57P02", "57P03":
+			return sqlx.SqlErrTypeConnectionLost
+		}
+		// connection_exception
+		if pgErr.Code.Class() == "08" {
+			return sqlx.SqlErrTypeConnectionLost
+		}
+		return ""
+	}
+
+	if errors.Is(err, driver.ErrBadConn) {
+		return sqlx.SqlErrTypeConnectionLost
+	}
+
+	return ""
+}
+
+// ConflictKey resolve key by table and constraint of postgres error,
+// index is named as <table>_<key> and primary key is named as <table>_pkey.
+func (PostgreSQLConnector) ConflictKey(err error, tables *builder.Tables) *builder.Key {
+	pgErr := &pq.Error{}
+	if !errors.As(err, &pgErr) || pgErr.Constraint == "" {
+		return nil
+	}
+
+	table := tables.Table(pgErr.Table)
+	if table == nil {
+		return nil
+	}
+
+	if pgErr.Constraint == table.Name+"_pkey" {
+		var primary *builder.Key
+		table.Keys.Range(func(key *builder.Key, idx int) {
+			if primary == nil && key.IsPrimary() {
+				primary = key
+			}
+		})
+		return primary
+	}
+
+	if !strings.HasPrefix(pgErr.Constraint, table.Name+"_") {
+		return nil
+	}
+
+	return table.Keys.Key(strings.TrimPrefix(pgErr.Constraint, table.Name+"_"))
+}
+
 func (c *PostgreSQLConnector) CreateDatabase(dbName string) builder.SqlExpr {
 	e := builder.Expr("CREATE DATABASE ")
 	e.WriteQuery(dbName)
@@ -186,6 +289,27 @@ func (c *PostgreSQLConnector) DropDatabase(dbName string) builder.SqlExpr {
 	return e
 }
 
+func (c *PostgreSQLConnector) Savepoint(name string) builder.SqlExpr {
+	e := builder.Expr("SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *PostgreSQLConnector) RollbackToSavepoint(name string) builder.SqlExpr {
+	e := builder.Expr("ROLLBACK TO SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *PostgreSQLConnector) ReleaseSavepoint(name string) builder.SqlExpr {
+	e := builder.Expr("RELEASE SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
 func (c *PostgreSQLConnector) AddIndex(key *builder.Key) builder.SqlExpr {
 	if key.IsPrimary() {
 		e := builder.Expr("ALTER TABLE ")
@@ -0,0 +1,176 @@
+package postgresql
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// replaceValueHolder rewrites ? to $N for lib/pq.
+//
+// ? in string literals, dollar-quoted bodies, quoted identifiers and comments will be kept,
+// and ?? could be used as escape of the operator ? (like ??, ??| and ??& for jsonb).
+//
+// when query contains any ? as value holder, the count of them must be equal to argsLen;
+// the query without ? will be returned as is, for the query may use $N directly.
+// negative argsLen skips the check, for statements prepared without args.
+func replaceValueHolder(query string, argsLen int) (string, error) {
+	data := []byte(query)
+	n := len(data)
+
+	size := n
+	if argsLen > 0 {
+		size += argsLen
+	}
+
+	e := bytes.NewBuffer(make([]byte, 0, size))
+
+	index := 0
+
+	for i := 0; i < n; i++ {
+		c := data[i]
+
+		switch c {
+		case '\'':
+			end := skipQuoted(data, i, '\'', isEscapeString(data, i))
+			e.Write(data[i:end])
+			i = end - 1
+		case '"':
+			end := skipQuoted(data, i, '"', false)
+			e.Write(data[i:end])
+			i = end - 1
+		case '$':
+			if tag, ok := dollarQuoteTag(data, i); ok {
+				end := skipDollarQuoted(data, i, tag)
+				e.Write(data[i:end])
+				i = end - 1
+				continue
+			}
+			e.WriteByte(c)
+		case '-':
+			if i+1 < n && data[i+1] == '-' {
+				end := bytes.IndexByte(data[i:], '\n')
+				if end == -1 {
+					end = n
+				} else {
+					end = i + end
+				}
+				e.Write(data[i:end])
+				i = end - 1
+				continue
+			}
+			e.WriteByte(c)
+		case '/':
+			if i+1 < n && data[i+1] == '*' {
+				end := skipBlockComment(data, i)
+				e.Write(data[i:end])
+				i = end - 1
+				continue
+			}
+			e.WriteByte(c)
+		case '?':
+			if i+1 < n && data[i+1] == '?' {
+				// escaped
+				e.WriteByte('?')
+				i++
+				continue
+			}
+			index++
+			e.WriteByte('$')
+			e.WriteString(strconv.FormatInt(int64(index), 10))
+		default:
+			e.WriteByte(c)
+		}
+	}
+
+	if argsLen >= 0 && index > 0 && index != argsLen {
+		return "", fmt.Errorf("sql: expected %d arguments, got %d, in query: %s", index, argsLen, query)
+	}
+
+	return e.String(), nil
+}
+
+// E'...' supports backslash escapes
+func isEscapeString(data []byte, i int) bool {
+	if i == 0 || (data[i-1] != 'E' && data[i-1] != 'e') {
+		return false
+	}
+	return i == 1 || !isIdentChar(data[i-2])
+}
+
+// skipQuoted returns the index after the closing quote, doubled quote is escaped
+func skipQuoted(data []byte, start int, quote byte, backslashEscape bool) int {
+	n := len(data)
+
+	for i := start + 1; i < n; i++ {
+		switch data[i] {
+		case '\\':
+			if backslashEscape {
+				i++
+			}
+		case quote:
+			if i+1 < n && data[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+
+	return n
+}
+
+// dollarQuoteTag matches $$ or $tag$, $1 is not a dollar quote
+func dollarQuoteTag(data []byte, start int) ([]byte, bool) {
+	n := len(data)
+
+	for i := start + 1; i < n; i++ {
+		c := data[i]
+		if c == '$' {
+			return data[start : i+1], true
+		}
+		if !isIdentChar(c) || (i == start+1 && c >= '0' && c <= '9') {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+func skipDollarQuoted(data []byte, start int, tag []byte) int {
+	from := start + len(tag)
+	if end := bytes.Index(data[from:], tag); end != -1 {
+		return from + end + len(tag)
+	}
+	return len(data)
+}
+
+// skipBlockComment block comments could be nested in postgres
+func skipBlockComment(data []byte, start int) int {
+	n := len(data)
+	depth := 0
+
+	for i := start; i < n; i++ {
+		if i+1 < n {
+			if data[i] == '/' && data[i+1] == '*' {
+				depth++
+				i++
+				continue
+			}
+			if data[i] == '*' && data[i+1] == '/' {
+				depth--
+				i++
+				if depth == 0 {
+					return i + 1
+				}
+				continue
+			}
+		}
+	}
+
+	return n
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
@@ -0,0 +1,91 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestReplaceValueHolder(t *testing.T) {
+	cases := []struct {
+		name    string
+		query   string
+		argsLen int
+		expect  string
+	}{
+		{
+			"simple",
+			`SELECT * FROM t WHERE f_a = ? AND f_b IN (?,?)`, 3,
+			`SELECT * FROM t WHERE f_a = $1 AND f_b IN ($2,$3)`,
+		},
+		{
+			"string literal",
+			`SELECT * FROM t WHERE f_a = 'what?' AND f_b = 'it''s?' AND f_c = ?`, 1,
+			`SELECT * FROM t WHERE f_a = 'what?' AND f_b = 'it''s?' AND f_c = $1`,
+		},
+		{
+			"escape string",
+			`SELECT E'\'?', ?`, 1,
+			`SELECT E'\'?', $1`,
+		},
+		{
+			"quoted identifier",
+			`SELECT "f_?" FROM t WHERE f_a = ?`, 1,
+			`SELECT "f_?" FROM t WHERE f_a = $1`,
+		},
+		{
+			"dollar quoted",
+			`SELECT $$?$$, $tag$ it's ? $tag$, ?`, 1,
+			`SELECT $$?$$, $tag$ it's ? $tag$, $1`,
+		},
+		{
+			"comments",
+			"SELECT ? -- ?\nFROM t /* ? /* ? */ ? */ WHERE f_a = ?", 2,
+			"SELECT $1 -- ?\nFROM t /* ? /* ? */ ? */ WHERE f_a = $2",
+		},
+		{
+			"jsonb operators",
+			`SELECT * FROM t WHERE f_data ?? ? AND f_data ??| ? AND f_data ??& ?`, 3,
+			`SELECT * FROM t WHERE f_data ? $1 AND f_data ?| $2 AND f_data ?& $3`,
+		},
+		{
+			"native value holder",
+			`SELECT * FROM t WHERE f_a = $1`, 1,
+			`SELECT * FROM t WHERE f_a = $1`,
+		},
+		{
+			"prepare without args",
+			`SELECT * FROM t WHERE f_a = ? AND f_b = ?`, -1,
+			`SELECT * FROM t WHERE f_a = $1 AND f_b = $2`,
+		},
+	}
+
+	for i := range cases {
+		c := cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			q, err := replaceValueHolder(c.query, c.argsLen)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(q).To(gomega.Equal(c.expect))
+		})
+	}
+
+	t.Run("escaped operator from builder", func(t *testing.T) {
+		e := builder.ResolveExpr(
+			builder.Select(nil).From(
+				builder.T("t"),
+				builder.Where(builder.AsCond(builder.Expr("? ?? ?", builder.Col("f_data"), "key"))),
+			),
+		)
+
+		q, err := replaceValueHolder(e.Query(), len(e.Args()))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(q).To(gomega.Equal("SELECT * FROM t\nWHERE f_data ? $1"))
+		gomega.NewWithT(t).Expect(e.Args()).To(gomega.Equal([]interface{}{"key"}))
+	})
+
+	t.Run("args not match", func(t *testing.T) {
+		_, err := replaceValueHolder(`SELECT * FROM t WHERE f_a = ? AND f_b = ?`, 1)
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+}
@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/connectors/stmtcache"
+
+	"github.com/go-courier/logr"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+var _ interface {
+	driver.Driver
+} = (*SQLiteLoggingDriver)(nil)
+
+type SQLiteLoggingDriver struct {
+	driver         sqlite3.SQLiteDriver
+	stmtCacheSize  int
+	stmtCacheStats *stmtcache.Stats
+}
+
+func (d *SQLiteLoggingDriver) Open(dsn string) (driver.Conn, error) {
+	conn, err := d.driver.Open(dsn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open connection: %s", dsn)
+	}
+	c := &loggerConn{Conn: conn}
+	if d.stmtCacheSize > 0 {
+		c.stmtCache = stmtcache.New(d.stmtCacheSize, d.stmtCacheStats)
+	}
+	return c, nil
+}
+
+var _ interface {
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+} = (*loggerConn)(nil)
+
+type loggerConn struct {
+	driver.Conn
+	stmtCache *stmtcache.Cache
+}
+
+func (c *loggerConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	logger := logr.FromContext(ctx)
+
+	logger.Debug("=========== Beginning Transaction ===========")
+	tx, err := c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to begin transaction"))
+		return nil, err
+	}
+	return &loggingTx{tx: tx, logger: logger}, nil
+}
+
+func (c *loggerConn) Close() error {
+	if c.stmtCache != nil {
+		_ = c.stmtCache.Close()
+	}
+	if err := c.Conn.Close(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *loggerConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *loggerConn) PrepareContext(ctx context.Context, query string) (stmt driver.Stmt, err error) {
+	newCtx, logger := logr.Start(ctx, "Prepare")
+	cost := startTimer()
+
+	defer func() {
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "prepare failed: %s", query))
+		} else {
+			logger.WithValues("cost", cost().String()).Debug(query)
+		}
+
+		logger.End()
+	}()
+
+	stmt, err = c.prepare(newCtx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &loggingStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+func (c *loggerConn) prepare(ctx context.Context, query string) (driver.Stmt, error) {
+	return stmtcache.Prepare(ctx, c.Conn, query)
+}
+
+func (c *loggerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.queryContext(ctx, query, args, func(ctx context.Context) (driver.Rows, error) {
+		if c.stmtCache != nil && stmtcache.IsCacheable(query) {
+			return c.stmtCache.Query(ctx, query, args, c.prepare)
+		}
+		return c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	})
+}
+
+func (c *loggerConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.execContext(ctx, query, args, func(ctx context.Context) (driver.Result, error) {
+		if c.stmtCache != nil && stmtcache.IsCacheable(query) {
+			return c.stmtCache.Exec(ctx, query, args, c.prepare)
+		}
+		return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+	})
+}
+
+func (c *loggerConn) queryContext(ctx context.Context, query string, args []driver.NamedValue, do func(ctx context.Context) (driver.Rows, error)) (rows driver.Rows, err error) {
+	newCtx, logger := logr.Start(ctx, "Query")
+	cost := startTimer()
+
+	defer func() {
+		q := interpolateParams(query, args)
+
+		if err != nil {
+			if sqliteErr, ok := sqlx.UnwrapAll(err).(sqlite3.Error); !ok {
+				logger.Error(errors.Wrapf(err, "query failed: %s", q))
+			} else {
+				logger.Warn(errors.Wrapf(sqliteErr, "query failed: %s", q))
+			}
+		} else {
+			logger.WithValues("cost", cost().String()).Debug("%s", q)
+		}
+
+		logger.End()
+	}()
+
+	rows, err = do(newCtx)
+	return
+}
+
+func (c *loggerConn) execContext(ctx context.Context, query string, args []driver.NamedValue, do func(ctx context.Context) (driver.Result, error)) (result driver.Result, err error) {
+	cost := startTimer()
+	newCtx, logger := logr.Start(ctx, "Exec")
+
+	defer func() {
+		q := interpolateParams(query, args)
+
+		if err != nil {
+			if sqliteErr, ok := sqlx.UnwrapAll(err).(sqlite3.Error); !ok {
+				logger.Error(errors.Wrapf(err, "exec failed: %s", q))
+			} else if sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+				logger.Warn(errors.Wrapf(sqliteErr, "exec failed: %s", q))
+			} else {
+				logger.Error(errors.Wrapf(sqliteErr, "exec failed: %s", q))
+			}
+		} else {
+			logger.WithValues("cost", cost().String()).Debug(q.String())
+		}
+
+		logger.End()
+	}()
+
+	result, err = do(newCtx)
+	return
+}
+
+var _ interface {
+	driver.StmtExecContext
+	driver.StmtQueryContext
+} = (*loggingStmt)(nil)
+
+type loggingStmt struct {
+	driver.Stmt
+	conn  *loggerConn
+	query string
+}
+
+func (s *loggingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), stmtcache.NamedValues(args))
+}
+
+func (s *loggingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), stmtcache.NamedValues(args))
+}
+
+func (s *loggingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.execContext(ctx, s.query, args, func(ctx context.Context) (driver.Result, error) {
+		return stmtcache.Exec(ctx, s.Stmt, args)
+	})
+}
+
+func (s *loggingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.queryContext(ctx, s.query, args, func(ctx context.Context) (driver.Rows, error) {
+		return stmtcache.Query(ctx, s.Stmt, args)
+	})
+}
+
+func startTimer() func() time.Duration {
+	startTime := time.Now()
+	return func() time.Duration {
+		return time.Since(startTime)
+	}
+}
+
+type loggingTx struct {
+	logger logr.Logger
+	tx     driver.Tx
+}
+
+func (tx *loggingTx) Commit() error {
+	if err := tx.tx.Commit(); err != nil {
+		tx.logger.Debug("failed to commit transaction: %s", err)
+		return err
+	}
+	tx.logger.Debug("=========== Committed Transaction ===========")
+	return nil
+}
+
+func (tx *loggingTx) Rollback() error {
+	if err := tx.tx.Rollback(); err != nil {
+		tx.logger.Debug("failed to rollback transaction: %s", err)
+		return err
+	}
+	tx.logger.Debug("=========== Rollback Transaction ===========")
+	return nil
+}
@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func interpolateParams(query string, args []driver.NamedValue) fmt.Stringer {
+	return &SqlPrinter{
+		query: query,
+		args:  args,
+	}
+}
+
+type SqlPrinter struct {
+	query string
+	args  []driver.NamedValue
+}
+
+func (p *SqlPrinter) String() string {
+	if len(p.args) == 0 {
+		return p.query
+	}
+	s, err := InterpolateParams(p.query, p.args)
+	if err != nil {
+		return p.query
+	}
+	return s
+}
+
+// InterpolateParams only for logging
+func InterpolateParams(query string, args []driver.NamedValue) (string, error) {
+	if strings.Count(query, "?") != len(args) {
+		return "", driver.ErrSkip
+	}
+
+	buf := make([]byte, 0, len(query))
+
+	argPos := 0
+
+	for i := 0; i < len(query); i++ {
+		q := query[i]
+
+		switch q {
+		case '?':
+			arg := args[argPos].Value
+			argPos++
+
+			switch v := arg.(type) {
+			case nil:
+				buf = append(buf, "NULL"...)
+			case int64:
+				buf = strconv.AppendInt(buf, v, 10)
+			case float64:
+				buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
+			case bool:
+				if v {
+					buf = append(buf, '1')
+				} else {
+					buf = append(buf, '0')
+				}
+			case time.Time:
+				buf = append(buf, '\'')
+				buf = append(buf, v.Format("2006-01-02 15:04:05.999999999-07:00")...)
+				buf = append(buf, '\'')
+			case []byte:
+				buf = append(buf, "X'"...)
+				buf = append(buf, hex.EncodeToString(v)...)
+				buf = append(buf, '\'')
+			case string:
+				buf = append(buf, '\'')
+				buf = append(buf, strings.Replace(v, "'", "''", -1)...)
+				buf = append(buf, '\'')
+			default:
+				return "", fmt.Errorf("unsupported type %T: %v", v, v)
+			}
+		case '\n':
+			buf = append(buf, ' ')
+		default:
+			buf = append(buf, q)
+		}
+	}
+
+	return string(buf), nil
+}
@@ -0,0 +1,236 @@
+package sqlite
+
+import (
+	"database/sql"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+)
+
+func toInterfaces(list ...string) []interface{} {
+	s := make([]interface{}, len(list))
+	for i, v := range list {
+		s[i] = v
+	}
+	return s
+}
+
+var reAutoIncrement = regexp.MustCompile(`(?i)\bAUTOINCREMENT\b`)
+var reIndexOn = regexp.MustCompile(`(?is)\bON\s+[^\s(]+\s*(\(.*\))\s*$`)
+
+func dbFromSqliteMaster(db sqlx.DBExecutor) (*sqlx.Database, error) {
+	d := db.D()
+	tableNames := d.Tables.TableNames()
+
+	database := sqlx.NewDatabase(d.Name)
+
+	tableColumnSchema := SchemaDatabase.T(&ColumnSchema{})
+	columnSchemaList := make([]ColumnSchema, 0)
+
+	err := db.QueryExprAndScan(
+		builder.Select(tableColumnSchema.Columns.Clone()).
+			From(tableColumnSchema,
+				builder.Where(
+					tableColumnSchema.F("TABLE_NAME").In(toInterfaces(tableNames...)...),
+				),
+				builder.OrderBy(
+					builder.AscOrder(tableColumnSchema.F("TABLE_NAME")),
+					builder.AscOrder(tableColumnSchema.F("CID")),
+				),
+			),
+		&columnSchemaList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	primaryKeys := map[string][]ColumnSchema{}
+
+	for i := range columnSchemaList {
+		columnSchema := columnSchemaList[i]
+
+		table := database.Table(columnSchema.TABLE_NAME)
+		if table == nil {
+			table = builder.T(columnSchema.TABLE_NAME)
+			database.AddTable(table)
+		}
+
+		table.AddCol(colFromColumnSchema(&columnSchema))
+
+		if columnSchema.PK > 0 {
+			primaryKeys[columnSchema.TABLE_NAME] = append(primaryKeys[columnSchema.TABLE_NAME], columnSchema)
+		}
+	}
+
+	for tableName, columnSchemaList := range primaryKeys {
+		sort.Slice(columnSchemaList, func(i, j int) bool {
+			return columnSchemaList[i].PK < columnSchemaList[j].PK
+		})
+
+		key := &builder.Key{}
+		key.Name = "primary"
+		key.IsUnique = true
+
+		for _, columnSchema := range columnSchemaList {
+			key.Def.ColNames = append(key.Def.ColNames, strings.ToLower(columnSchema.COLUMN_NAME))
+		}
+
+		database.Table(tableName).AddKey(key)
+	}
+
+	if len(columnSchemaList) != 0 {
+		tableIndexSchema := SchemaDatabase.T(&IndexSchema{})
+
+		indexList := make([]IndexSchema, 0)
+
+		err = db.QueryExprAndScan(
+			builder.Select(tableIndexSchema.Columns.Clone()).
+				From(
+					tableIndexSchema,
+					builder.Where(
+						tableIndexSchema.F("TABLE_NAME").In(toInterfaces(tableNames...)...),
+					),
+					builder.OrderBy(
+						builder.AscOrder(tableIndexSchema.F("INDEX_NAME")),
+						builder.AscOrder(tableIndexSchema.F("SEQ_IN_INDEX")),
+					),
+				),
+			&indexList,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, indexSchema := range indexList {
+			table := database.Table(indexSchema.TABLE_NAME)
+			name := strings.ToLower(strings.TrimPrefix(indexSchema.INDEX_NAME, table.Name+"_"))
+
+			key := table.Keys.Key(name)
+			if key == nil {
+				key = &builder.Key{}
+				key.Name = name
+				key.IsUnique = indexSchema.NON_UNIQUE == 0
+				table.AddKey(key)
+				key = table.Keys.Key(name)
+			}
+
+			if key.Def.Expr != "" {
+				continue
+			}
+
+			if !indexSchema.COLUMN_NAME.Valid {
+				// index on expressions
+				if matched := reIndexOn.FindStringSubmatch(indexSchema.INDEX_DEF); len(matched) == 2 {
+					key.Def.ColNames = nil
+					key.Def.Expr = matched[1]
+				}
+				continue
+			}
+
+			key.Def.ColNames = append(key.Def.ColNames, strings.ToLower(indexSchema.COLUMN_NAME.String))
+		}
+	}
+
+	return database, nil
+}
+
+var SchemaDatabase = sqlx.NewDatabase("sqlite_master")
+
+func init() {
+	SchemaDatabase.Register(&ColumnSchema{})
+	SchemaDatabase.Register(&IndexSchema{})
+}
+
+func colFromColumnSchema(columnSchema *ColumnSchema) *builder.Column {
+	col := builder.Col(columnSchema.COLUMN_NAME)
+
+	dataType := strings.ToLower(columnSchema.DATA_TYPE)
+
+	col.AutoIncrement = columnSchema.PK > 0 && dataType == "integer" && reAutoIncrement.MatchString(columnSchema.TABLE_SQL)
+
+	if columnSchema.COLUMN_DEFAULT.Valid {
+		v := columnSchema.COLUMN_DEFAULT.String
+		col.Default = &v
+	}
+
+	// varchar(255) decimal(10,2)
+	if i := strings.Index(dataType, "("); i > 0 && strings.HasSuffix(dataType, ")") {
+		sizes := strings.Split(dataType[i+1:len(dataType)-1], ",")
+
+		length, err := strconv.ParseUint(strings.TrimSpace(sizes[0]), 10, 64)
+		if err == nil {
+			col.Length = length
+			if len(sizes) > 1 {
+				col.Decimal, _ = strconv.ParseUint(strings.TrimSpace(sizes[1]), 10, 64)
+			}
+			dataType = strings.TrimSpace(dataType[0:i])
+		}
+	}
+
+	col.DataType = dataType
+
+	if columnSchema.NOT_NULL == 0 {
+		col.Null = true
+	}
+
+	return col
+}
+
+type ColumnSchema struct {
+	TABLE_NAME     string         `db:"table_name"`
+	TABLE_SQL      string         `db:"table_sql"`
+	CID            int32          `db:"cid"`
+	COLUMN_NAME    string         `db:"column_name"`
+	DATA_TYPE      string         `db:"data_type"`
+	NOT_NULL       int32          `db:"not_null"`
+	COLUMN_DEFAULT sql.NullString `db:"column_default"`
+	PK             int32          `db:"pk"`
+}
+
+func (ColumnSchema) TableName() string {
+	return `
+	(SELECT m.name AS table_name,
+	m.sql AS table_sql,
+	p.cid AS cid,
+	p.name AS column_name,
+	p.type AS data_type,
+	p."notnull" AS not_null,
+	p.dflt_value AS column_default,
+	p.pk AS pk
+	FROM sqlite_master m
+	JOIN pragma_table_info(m.name) p
+	WHERE m.type = 'table') AS columns
+	`
+}
+
+type IndexSchema struct {
+	TABLE_NAME   string         `db:"table_name"`
+	INDEX_NAME   string         `db:"index_name"`
+	NON_UNIQUE   int32          `db:"non_unique"`
+	SEQ_IN_INDEX int32          `db:"seq_in_index"`
+	COLUMN_NAME  sql.NullString `db:"column_name"`
+	INDEX_DEF    string         `db:"index_def"`
+}
+
+func (IndexSchema) TableName() string {
+	// only indexes created by CREATE INDEX (origin = 'c'),
+	// primary key and unique constraints are resolved from table info.
+	return `
+	(SELECT m.name AS table_name,
+	il.name AS index_name,
+	1 - il."unique" AS non_unique,
+	ii.seqno AS seq_in_index,
+	ii.name AS column_name,
+	i.sql AS index_def
+	FROM sqlite_master m
+	JOIN pragma_index_list(m.name) il
+	JOIN pragma_index_info(il.name) ii
+	JOIN sqlite_master i ON i.type = 'index' AND i.name = il.name
+	WHERE m.type = 'table' AND il.origin = 'c') AS indexes
+	`
+}
@@ -0,0 +1,667 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	typex "github.com/go-courier/x/types"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/connectors/stmtcache"
+	"github.com/kunlun-qilian/sqlx/v3/migration"
+	"github.com/mattn/go-sqlite3"
+)
+
+var _ interface {
+	driver.Connector
+	builder.Dialect
+} = (*SQLiteConnector)(nil)
+
+// Memory as File of SQLiteConnector to use an in-memory database,
+// which shared by all connections of the process with same DBName.
+const Memory = ":memory:"
+
+type SQLiteConnector struct {
+	// File path of database file, default as {DBName}.db
+	File   string
+	DBName string
+	Extra  string
+	// Functions will be registered as sql functions for each connection
+	Functions map[string]interface{}
+	// StmtCacheSize max count of prepared statements cached for each connection,
+	// statement cache disabled when zero
+	StmtCacheSize int
+
+	stmtCacheStats *stmtcache.Stats
+}
+
+func (c *SQLiteConnector) dsn() string {
+	extra := c.Extra
+
+	file := c.File
+	switch file {
+	case "":
+		file = c.DBName + ".db"
+	case Memory:
+		file = "file:" + c.DBName
+		if extra != "" {
+			extra = "mode=memory&cache=shared&" + extra
+		} else {
+			extra = "mode=memory&cache=shared"
+		}
+	}
+
+	if extra != "" {
+		return file + "?" + extra
+	}
+	return file
+}
+
+func (c SQLiteConnector) WithDBName(dbName string) driver.Connector {
+	c.DBName = dbName
+	if c.StmtCacheSize > 0 {
+		c.stmtCacheStats = &stmtcache.Stats{}
+	}
+	return &c
+}
+
+// StmtCacheStats returns hit and miss stats of statement caches of all connections
+func (c *SQLiteConnector) StmtCacheStats() *stmtcache.Stats {
+	return c.stmtCacheStats
+}
+
+func (c *SQLiteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.Driver().Open(c.dsn())
+}
+
+func (c *SQLiteConnector) Driver() driver.Driver {
+	return &SQLiteLoggingDriver{
+		driver: sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				for name, fn := range c.Functions {
+					if err := conn.RegisterFunc(name, fn, true); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		stmtCacheSize:  c.StmtCacheSize,
+		stmtCacheStats: c.stmtCacheStats,
+	}
+}
+
+func (c *SQLiteConnector) Migrate(ctx context.Context, db sqlx.DBExecutor) error {
+	output := migration.MigrationOutputFromContext(ctx)
+
+	// sqlite without schema
+	d := db.D().WithSchema("")
+	dialect := db.Dialect()
+
+	prevDB, err := dbFromSqliteMaster(db)
+	if err != nil {
+		return err
+	}
+
+	exec := func(expr builder.SqlExpr) error {
+		if expr == nil || expr.IsNil() {
+			return nil
+		}
+
+		if output != nil {
+			_, _ = io.WriteString(output, builder.ResolveExpr(expr).Query())
+			_, _ = io.WriteString(output, "\n")
+			return nil
+		}
+
+		_, err := db.ExecExpr(expr)
+		return err
+	}
+
+	for _, name := range d.Tables.TableNames() {
+		table := d.Tables.Table(name)
+		prevTable := prevDB.Table(name)
+
+		if prevTable == nil {
+			for _, expr := range dialect.CreateTableIsNotExists(table) {
+				if err := exec(expr); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		exprList := table.Diff(prevTable, dialect)
+
+		for _, expr := range exprList {
+			if err := exec(expr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// MaxParams SQLITE_MAX_VARIABLE_NUMBER defaults to 32766 since 3.32.0
+func (SQLiteConnector) MaxParams() int {
+	return 32766
+}
+
+func (SQLiteConnector) DriverName() string {
+	return "sqlite"
+}
+
+func (SQLiteConnector) PrimaryKeyName() string {
+	return "primary"
+}
+
+func (SQLiteConnector) IsErrorUnknownDatabase(err error) bool {
+	return false
+}
+
+func (SQLiteConnector) IsErrorConflict(err error) bool {
+	e := sqlite3.Error{}
+	if errors.As(err, &e) {
+		return e.ExtendedCode == sqlite3.ErrConstraintUnique || e.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	}
+	return false
+}
+
+// IsErrorRetryable database is locked by other connection
+func (SQLiteConnector) IsErrorRetryable(err error) bool {
+	e := sqlite3.Error{}
+	if errors.As(err, &e) {
+		return e.Code == sqlite3.ErrBusy || e.Code == sqlite3.ErrLocked
+	}
+	return false
+}
+
+// ClassifyError classify error by extended code of sqlite error
+func (SQLiteConnector) ClassifyError(err error) sqlx.SqlErrType {
+	e := sqlite3.Error{}
+	if errors.As(err, &e) {
+		switch e.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return sqlx.SqlErrTypeConflict
+		case sqlite3.ErrConstraintForeignKey:
+			return sqlx.SqlErrTypeForeignKeyViolation
+		case sqlite3.ErrConstraintNotNull:
+			return sqlx.SqlErrTypeNotNullViolation
+		case sqlite3.ErrConstraintCheck:
+			return sqlx.SqlErrTypeCheckViolation
+		}
+		switch e.Code {
+		case sqlite3.ErrTooBig:
+			return sqlx.SqlErrTypeValueTooLong
+		case sqlite3.ErrBusy, sqlite3.ErrLocked:
+			return sqlx.SqlErrTypeLockTimeout
+		case sqlite3.ErrInterrupt:
+			return sqlx.SqlErrTypeCanceled
+		}
+	}
+	return ""
+}
+
+// ConflictKey resolve key by columns in message of unique constraint error,
+// like "UNIQUE constraint failed: t_user.f_name, t_user.f_org_id",
+// or by index name for index on expressions, like "UNIQUE constraint failed: index 't_user_i_name'"
+func (SQLiteConnector) ConflictKey(err error, tables *builder.Tables) *builder.Key {
+	e := sqlite3.Error{}
+	if !errors.As(err, &e) || (e.ExtendedCode != sqlite3.ErrConstraintUnique && e.ExtendedCode != sqlite3.ErrConstraintPrimaryKey) {
+		return nil
+	}
+
+	msg := e.Error()
+
+	i := strings.Index(msg, "constraint failed: ")
+	if i < 0 {
+		return nil
+	}
+	msg = msg[i+len("constraint failed: "):]
+
+	if strings.HasPrefix(msg, "index '") {
+		indexName := strings.TrimSuffix(strings.TrimPrefix(msg, "index '"), "'")
+
+		var found *builder.Key
+		tables.Range(func(table *builder.Table, idx int) {
+			if found == nil && strings.HasPrefix(indexName, table.Name+"_") {
+				found = table.Keys.Key(strings.TrimPrefix(indexName, table.Name+"_"))
+			}
+		})
+		return found
+	}
+
+	tableName := ""
+	colNames := make([]string, 0)
+
+	for _, part := range strings.Split(msg, ",") {
+		tableAndCol := strings.SplitN(strings.TrimSpace(part), ".", 2)
+		if len(tableAndCol) != 2 {
+			return nil
+		}
+		tableName = tableAndCol[0]
+		colNames = append(colNames, tableAndCol[1])
+	}
+
+	table := tables.Table(tableName)
+	if table == nil {
+		return nil
+	}
+
+	var found *builder.Key
+	table.Keys.Range(func(key *builder.Key, idx int) {
+		if found == nil && key.IsUnique && isSameColNames(key.ColNames(), colNames) {
+			found = key
+		}
+	})
+	return found
+}
+
+func isSameColNames(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateDatabase sqlite database is created with the file when connecting
+func (c *SQLiteConnector) CreateDatabase(dbName string) builder.SqlExpr {
+	return nil
+}
+
+// CreateSchema sqlite without schema
+func (c *SQLiteConnector) CreateSchema(schema string) builder.SqlExpr {
+	return nil
+}
+
+// DropDatabase sqlite database should be dropped by removing the file
+func (c *SQLiteConnector) DropDatabase(dbName string) builder.SqlExpr {
+	return nil
+}
+
+func (c *SQLiteConnector) Savepoint(name string) builder.SqlExpr {
+	e := builder.Expr("SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) RollbackToSavepoint(name string) builder.SqlExpr {
+	e := builder.Expr("ROLLBACK TO SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) ReleaseSavepoint(name string) builder.SqlExpr {
+	e := builder.Expr("RELEASE SAVEPOINT ")
+	e.WriteQuery(name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) AddIndex(key *builder.Key) builder.SqlExpr {
+	if key.IsPrimary() {
+		// sqlite can't alter primary key, so rebuild the table with it
+		return c.rebuildTable(key.Table, key.Table.Columns.Clone())
+	}
+
+	e := builder.Expr("CREATE ")
+	if key.IsUnique {
+		e.WriteQuery("UNIQUE ")
+	}
+	e.WriteQuery("INDEX IF NOT EXISTS ")
+
+	e.WriteQuery(key.Table.Name)
+	e.WriteQuery("_")
+	e.WriteQuery(key.Name)
+
+	e.WriteQuery(" ON ")
+	e.WriteExpr(key.Table)
+
+	e.WriteQueryByte(' ')
+	e.WriteExpr(key.Def.TableExpr(key.Table))
+
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) DropIndex(key *builder.Key) builder.SqlExpr {
+	if key.IsPrimary() {
+		// primary key will be replaced when table rebuilt
+		return nil
+	}
+
+	e := builder.Expr("DROP INDEX IF EXISTS ")
+	e.WriteQuery(key.Table.Name)
+	e.WriteQueryByte('_')
+	e.WriteQuery(key.Name)
+	e.WriteEnd()
+
+	return e
+}
+
+func (c *SQLiteConnector) CreateTableIsNotExists(t *builder.Table) (exprs []builder.SqlExpr) {
+	exprs = append(exprs, c.createTable(t, "CREATE TABLE IF NOT EXISTS "))
+
+	t.Keys.Range(func(key *builder.Key, idx int) {
+		if !key.IsPrimary() && !key.IsPartition() {
+			exprs = append(exprs, c.AddIndex(key))
+		}
+	})
+
+	return
+}
+
+func (c *SQLiteConnector) createTable(t *builder.Table, prefix string) *builder.Ex {
+	expr := builder.Expr(prefix)
+	expr.WriteExpr(t)
+	expr.WriteQueryByte(' ')
+	expr.WriteGroup(func(e *builder.Ex) {
+		if t.Columns.IsNil() {
+			return
+		}
+
+		count := 0
+
+		t.Columns.Range(func(col *builder.Column, idx int) {
+			if col.DeprecatedActions != nil {
+				return
+			}
+
+			if count > 0 {
+				e.WriteQueryByte(',')
+			}
+			e.WriteQueryByte('\n')
+			e.WriteQueryByte('\t')
+
+			e.WriteExpr(col)
+			e.WriteQueryByte(' ')
+			e.WriteExpr(c.DataType(col.ColumnType))
+
+			count++
+		})
+
+		// AUTOINCREMENT is only allowed on an INTEGER PRIMARY KEY,
+		// so the auto increment column is always the primary key.
+		if t.AutoIncrement() == nil {
+			t.Keys.Range(func(key *builder.Key, idx int) {
+				if key.IsPrimary() {
+					e.WriteQueryByte(',')
+					e.WriteQueryByte('\n')
+					e.WriteQueryByte('\t')
+					e.WriteQuery("PRIMARY KEY ")
+					e.WriteExpr(key.Def.TableExpr(key.Table))
+				}
+			})
+		}
+
+		expr.WriteQueryByte('\n')
+	})
+
+	expr.WriteEnd()
+	return expr
+}
+
+// rebuildTable
+// sqlite only support limited ALTER TABLE,
+// so create a new table as target, copy data of columns, and then replace the old one.
+// https://www.sqlite.org/lang_altertable.html#otheralter
+func (c *SQLiteConnector) rebuildTable(target *builder.Table, columnsForCopy *builder.Columns) builder.SqlExpr {
+	tmpTable := builder.T(target.Name + "__rebuild")
+
+	target.Columns.Range(func(col *builder.Column, idx int) {
+		tmpTable.AddCol(col)
+	})
+
+	target.Keys.Range(func(key *builder.Key, idx int) {
+		if key.IsPrimary() {
+			tmpTable.AddKey(key)
+		}
+	})
+
+	colsForCopy := &builder.Columns{}
+
+	columnsForCopy.Range(func(col *builder.Column, idx int) {
+		if col.DeprecatedActions != nil {
+			return
+		}
+		if colForCopy := tmpTable.Col(col.Name); colForCopy != nil {
+			colsForCopy.Add(colForCopy)
+		}
+	})
+
+	exprs := []builder.SqlExpr{
+		c.createTable(tmpTable, "CREATE TABLE "),
+	}
+
+	if !colsForCopy.IsNil() {
+		e := builder.Insert().Into(tmpTable).Values(colsForCopy, builder.Select(colsForCopy).From(target))
+		exprs = append(exprs, builder.Expr("?;", e))
+	}
+
+	exprs = append(exprs, c.DropTable(target))
+
+	rename := builder.Expr("ALTER TABLE ")
+	rename.WriteExpr(tmpTable)
+	rename.WriteQuery(" RENAME TO ")
+	rename.WriteExpr(target)
+	rename.WriteEnd()
+
+	exprs = append(exprs, rename)
+
+	target.Keys.Range(func(key *builder.Key, idx int) {
+		if !key.IsPrimary() && !key.IsPartition() {
+			exprs = append(exprs, c.AddIndex(key))
+		}
+	})
+
+	return builder.MultiWith("\n", exprs...)
+}
+
+func (c *SQLiteConnector) DropTable(t *builder.Table) builder.SqlExpr {
+	e := builder.Expr("DROP TABLE IF EXISTS ")
+	e.WriteExpr(t)
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) TruncateTable(t *builder.Table) builder.SqlExpr {
+	e := builder.Expr("DELETE FROM ")
+	e.WriteExpr(t)
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) AddColumn(col *builder.Column) builder.SqlExpr {
+	e := builder.Expr("ALTER TABLE ")
+	e.WriteExpr(col.Table)
+	e.WriteQuery(" ADD COLUMN ")
+	e.WriteExpr(col)
+	e.WriteQueryByte(' ')
+	e.WriteExpr(c.DataType(col.ColumnType))
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) RenameColumn(col *builder.Column, target *builder.Column) builder.SqlExpr {
+	e := builder.Expr("ALTER TABLE ")
+	e.WriteExpr(col.Table)
+	e.WriteQuery(" RENAME COLUMN ")
+	e.WriteExpr(col)
+	e.WriteQuery(" TO ")
+	e.WriteExpr(target)
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) ModifyColumn(col *builder.Column, prev *builder.Column) builder.SqlExpr {
+	prevTable := prev.Table
+	if prevTable == nil || col.Table == nil {
+		return nil
+	}
+
+	// modify all columns in one rebuilding,
+	// so only rebuild for the first modified column, and skip for the rest columns.
+	var firstModified *builder.Column
+
+	target := builder.T(prevTable.Name)
+
+	prevTable.Columns.Range(func(prevCol *builder.Column, idx int) {
+		// copy of prev column, prev table should not be changed
+		targetCol := *prevCol
+
+		if currentCol := col.Table.Col(prevCol.Name); currentCol != nil && currentCol.DeprecatedActions == nil {
+			if firstModified == nil && c.isColumnTypeModified(currentCol, prevCol) {
+				firstModified = prevCol
+			}
+			targetCol.ColumnType = currentCol.ColumnType
+		}
+
+		target.AddCol(&targetCol)
+	})
+
+	if firstModified == nil || firstModified.Name != prev.Name {
+		return nil
+	}
+
+	prevTable.Keys.Range(func(key *builder.Key, idx int) {
+		target.AddKey(key)
+	})
+
+	return c.rebuildTable(target, prevTable.Columns.Clone())
+}
+
+func (c *SQLiteConnector) isColumnTypeModified(col *builder.Column, prev *builder.Column) bool {
+	return c.DataType(col.ColumnType).Ex(context.Background()).Query() != c.DataType(prev.ColumnType).Ex(context.Background()).Query()
+}
+
+func (c *SQLiteConnector) DropColumn(col *builder.Column) builder.SqlExpr {
+	e := builder.Expr("ALTER TABLE ")
+	e.WriteExpr(col.Table)
+	e.WriteQuery(" DROP COLUMN ")
+	e.WriteQuery(col.Name)
+	e.WriteEnd()
+	return e
+}
+
+func (c *SQLiteConnector) DataType(columnType *builder.ColumnType) builder.SqlExpr {
+	dbDataType := c.dbDataType(columnType.Type, columnType)
+	return builder.Expr(dbDataType + autocompleteSize(dbDataType, columnType) + c.dataTypeModify(columnType))
+}
+
+func (c *SQLiteConnector) dbDataType(typ typex.Type, columnType *builder.ColumnType) string {
+	if columnType.DataType != "" {
+		return columnType.DataType
+	}
+
+	// https://www.sqlite.org/autoinc.html
+	if columnType.AutoIncrement {
+		return "integer"
+	}
+
+	if rv, ok := typex.TryNew(typ); ok {
+		if dtd, ok := rv.Interface().(builder.DataTypeDescriber); ok {
+			return dtd.DataType(c.DriverName())
+		}
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		return c.dbDataType(typ.Elem(), columnType)
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "integer"
+	case reflect.Int64, reflect.Uint64:
+		return "bigint"
+	case reflect.Float32:
+		return "real"
+	case reflect.Float64:
+		return "double"
+	case reflect.String:
+		size := columnType.Length
+		if size < 65535/3 {
+			return "varchar"
+		}
+		return "text"
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "blob"
+		}
+	}
+
+	switch typ.Name() {
+	case "NullInt64":
+		return "bigint"
+	case "NullFloat64":
+		return "double"
+	case "NullBool":
+		return "boolean"
+	case "Time", "NullTime":
+		return "datetime"
+	}
+
+	panic(fmt.Errorf("unsupport type %s", typ))
+}
+
+func (c *SQLiteConnector) dataTypeModify(columnType *builder.ColumnType) string {
+	buf := bytes.NewBuffer(nil)
+
+	if columnType.AutoIncrement {
+		buf.WriteString(" PRIMARY KEY AUTOINCREMENT")
+		return buf.String()
+	}
+
+	if !columnType.Null {
+		buf.WriteString(" NOT NULL")
+	}
+
+	if columnType.Default != nil {
+		buf.WriteString(" DEFAULT ")
+		buf.WriteString(*columnType.Default)
+	}
+
+	return buf.String()
+}
+
+func autocompleteSize(dataType string, columnType *builder.ColumnType) string {
+	switch strings.ToLower(dataType) {
+	case "varchar":
+		size := columnType.Length
+		if size == 0 {
+			size = 255
+		}
+		return sizeModifier(size, columnType.Decimal)
+	case "decimal", "numeric":
+		if columnType.Length > 0 {
+			return sizeModifier(columnType.Length, columnType.Decimal)
+		}
+	}
+	return ""
+}
+
+func sizeModifier(length uint64, decimal uint64) string {
+	if length > 0 {
+		size := strconv.FormatUint(length, 10)
+		if decimal > 0 {
+			return "(" + size + "," + strconv.FormatUint(decimal, 10) + ")"
+		}
+		return "(" + size + ")"
+	}
+	return ""
+}
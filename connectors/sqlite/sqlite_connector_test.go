@@ -0,0 +1,254 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/builder/buidertestingutils"
+	"github.com/kunlun-qilian/sqlx/v3/migration"
+	"github.com/mattn/go-sqlite3"
+	"github.com/onsi/gomega"
+)
+
+func TestSQLiteConnector(t *testing.T) {
+	c := &SQLiteConnector{}
+
+	table := builder.T("t",
+		builder.Col("F_id").Type(uint64(0), ",autoincrement"),
+		builder.Col("f_old_name").Type("", ",deprecated=f_name"),
+		builder.Col("F_name").Type("", ",size=128,default=''"),
+		builder.Col("F_created_at").Type(int64(0), ",default='0'"),
+		builder.Col("F_updated_at").Type(int64(0), ",default='0'"),
+		builder.PrimaryKey(builder.Cols("F_id")),
+		builder.UniqueIndex("I_name", builder.Cols("F_name")).Using("BTREE"),
+		builder.Index("I_created_at", builder.Cols("F_created_at")).Using("BTREE"),
+	)
+
+	t.Run("AddIndex", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(c.AddIndex(table.Key("I_name"))).
+			To(buidertestingutils.BeExpr( /* language=SQLite */ `CREATE UNIQUE INDEX IF NOT EXISTS t_i_name ON t (f_name);`))
+	})
+	t.Run("DropIndex", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(c.DropIndex(table.Key("I_name"))).
+			To(buidertestingutils.BeExpr( /* language=SQLite */ `DROP INDEX IF EXISTS t_i_name;`))
+	})
+	t.Run("CreateTableIsNotExists", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(
+			c.CreateTableIsNotExists(table)[0],
+		).To(buidertestingutils.BeExpr( /* language=SQLite */
+			`CREATE TABLE IF NOT EXISTS t (
+	f_id integer PRIMARY KEY AUTOINCREMENT,
+	f_name varchar(128) NOT NULL DEFAULT '',
+	f_created_at bigint NOT NULL DEFAULT '0',
+	f_updated_at bigint NOT NULL DEFAULT '0'
+);`))
+	})
+	t.Run("TruncateTable", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(c.TruncateTable(table)).
+			To(buidertestingutils.BeExpr( /* language=SQLite */ "DELETE FROM t;"))
+	})
+	t.Run("ModifyColumn", func(t *testing.T) {
+		prevTable := builder.T("t",
+			builder.Col("f_id").Type(uint64(0), ",autoincrement"),
+			builder.Col("f_name").Type("", ",size=64,default=''"),
+			builder.PrimaryKey(builder.Cols("f_id")),
+			builder.UniqueIndex("I_name", builder.Cols("f_name")),
+		)
+
+		gomega.NewWithT(t).Expect(c.ModifyColumn(table.Col("F_name"), prevTable.Col("f_name"))).
+			To(buidertestingutils.BeExpr( /* language=SQLite */ `CREATE TABLE t__rebuild (
+	f_id integer PRIMARY KEY AUTOINCREMENT,
+	f_name varchar(128) NOT NULL DEFAULT ''
+);
+INSERT INTO t__rebuild (f_id,f_name) SELECT f_id,f_name FROM t;
+DROP TABLE IF EXISTS t;
+ALTER TABLE t__rebuild RENAME TO t;
+CREATE UNIQUE INDEX IF NOT EXISTS t_i_name ON t (f_name);`))
+
+		// prev table should not be changed
+		gomega.NewWithT(t).Expect(prevTable.Col("f_name").ColumnType.Length).To(gomega.Equal(uint64(64)))
+	})
+	t.Run("ModifyColumn once for multiple modified columns", func(t *testing.T) {
+		prevTable := builder.T("t",
+			builder.Col("f_id").Type(uint64(0), ",autoincrement"),
+			builder.Col("f_name").Type("", ",size=64,default=''"),
+			builder.Col("f_created_at").Type(int32(0), ",default='0'"),
+			builder.PrimaryKey(builder.Cols("f_id")),
+		)
+
+		exprList := table.Diff(prevTable, c)
+
+		rebuilds := 0
+		for _, e := range exprList {
+			if e != nil && !e.IsNil() && strings.Contains(builder.ResolveExpr(e).Query(), "t__rebuild") {
+				rebuilds++
+			}
+		}
+
+		gomega.NewWithT(t).Expect(rebuilds).To(gomega.Equal(1))
+		gomega.NewWithT(t).Expect(prevTable.Col("f_name").ColumnType.Length).To(gomega.Equal(uint64(64)))
+	})
+}
+
+type User struct {
+	ID       uint64 `db:"f_id,autoincrement"`
+	Name     string `db:"f_name,size=255,default=''"`
+	Nickname string `db:"f_nickname,size=255,default=''"`
+	Age      int32  `db:"f_age,default='0'"`
+}
+
+func (User) TableName() string {
+	return "t_user"
+}
+
+func (User) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (User) Indexes() builder.Indexes {
+	return builder.Indexes{
+		"i_nickname": {"Nickname"},
+	}
+}
+
+func (User) UniqueIndexes() builder.Indexes {
+	return builder.Indexes{
+		"i_name": {"Name"},
+	}
+}
+
+type User2 struct {
+	ID       uint64 `db:"f_id,autoincrement"`
+	Name     string `db:"f_name,size=512,default=''"`
+	Nickname string `db:"f_nickname,size=255,default=''"`
+	Age      int64  `db:"f_age,default='0'"`
+	Username string `db:"f_username,default=''"`
+}
+
+func (User2) TableName() string {
+	return "t_user"
+}
+
+func (User2) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (User2) UniqueIndexes() builder.Indexes {
+	return builder.Indexes{
+		"i_name": {"Name", "Username"},
+	}
+}
+
+// openDB opens db by connector, and closes it after test
+func openDB(t *testing.T, dbTest *sqlx.Database, connector *SQLiteConnector) *sqlx.DB {
+	db := dbTest.OpenDB(connector)
+	closeAfterTest(t, db)
+	return db
+}
+
+func closeAfterTest(t *testing.T, db *sqlx.DB) {
+	t.Cleanup(func() {
+		_ = db.SqlExecutor.(*sql.DB).Close()
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_migrate")
+
+	// in-memory database kept until closed after test
+	db := openDB(t, dbTest, &SQLiteConnector{File: Memory})
+
+	t.Run("create table", func(t *testing.T) {
+		dbTest.Register(&User{})
+
+		err := migration.Migrate(db, nil)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		t.Run("again without changes", func(t *testing.T) {
+			prevDB, err := dbFromSqliteMaster(db)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(db.T(&User{}).Diff(prevDB.Table("t_user"), db.Dialect())).To(gomega.HaveLen(0))
+		})
+
+		t.Run("crud", func(t *testing.T) {
+			for _, name := range []string{"a", "b"} {
+				_, err := db.ExecExpr(sqlx.InsertToDB(db, &User{Name: name, Age: 18}, nil))
+				gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			}
+
+			_, err := db.ExecExpr(sqlx.InsertToDB(db, &User{Name: "a"}, nil))
+			gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+
+			list := make([]User, 0)
+			err = db.QueryExprAndScan(builder.Select(nil).From(db.T(&User{})), &list)
+			gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+			gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+			gomega.NewWithT(t).Expect(list[0].ID).To(gomega.Equal(uint64(1)))
+		})
+	})
+
+	t.Run("migrate to user2", func(t *testing.T) {
+		dbTest.Register(&User2{})
+
+		err := migration.Migrate(db, nil)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		prevDB, err := dbFromSqliteMaster(db)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(db.T(&User2{}).Diff(prevDB.Table("t_user"), db.Dialect())).To(gomega.HaveLen(0))
+
+		list := make([]User2, 0)
+		err = db.QueryExprAndScan(builder.Select(nil).From(db.T(&User2{})), &list)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+		gomega.NewWithT(t).Expect(list[1].Name).To(gomega.Equal("b"))
+		gomega.NewWithT(t).Expect(list[1].Age).To(gomega.Equal(int64(18)))
+	})
+}
+
+func TestIsErrorRetryable(t *testing.T) {
+	c := &SQLiteConnector{}
+
+	gomega.NewWithT(t).Expect(c.IsErrorRetryable(sqlite3.Error{Code: sqlite3.ErrBusy})).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(c.IsErrorRetryable(sqlite3.Error{Code: sqlite3.ErrLocked})).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(c.IsErrorRetryable(sqlite3.Error{Code: sqlite3.ErrConstraint})).To(gomega.BeFalse())
+	gomega.NewWithT(t).Expect(c.IsErrorRetryable(errors.New("failed"))).To(gomega.BeFalse())
+}
+
+func TestClassifyError(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_classify_error")
+	dbTest.Register(&User{})
+
+	db := openDB(t, dbTest, &SQLiteConnector{File: filepath.Join(t.TempDir(), "test.db")})
+
+	err := migration.Migrate(db, nil)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	_, err = db.ExecExpr(sqlx.InsertToDB(db, &User{Name: "a"}, nil))
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := db.ExecExpr(sqlx.InsertToDB(db, &User{Name: "a"}, nil))
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).ConflictFields()).To(gomega.Equal([]string{"Name"}))
+	})
+
+	t.Run("conflict of primary key", func(t *testing.T) {
+		_, err := db.ExecExpr(builder.Expr("INSERT INTO t_user (f_id, f_name) VALUES (1, 'b')"))
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).ConflictFields()).To(gomega.Equal([]string{"ID"}))
+	})
+
+	t.Run("not null violation", func(t *testing.T) {
+		_, err := db.ExecExpr(builder.Expr("INSERT INTO t_user (f_name) VALUES (NULL)"))
+		gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsNotNullViolation()).To(gomega.BeTrue())
+
+		sqliteErr := sqlite3.Error{}
+		gomega.NewWithT(t).Expect(errors.As(err, &sqliteErr)).To(gomega.BeTrue())
+	})
+}
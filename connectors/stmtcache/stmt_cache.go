@@ -0,0 +1,275 @@
+package stmtcache
+
+import (
+	"container/list"
+	"context"
+	"database/sql/driver"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Stats hit and miss stats of statement caches, shared by connections
+type Stats struct {
+	hits   uint64
+	misses uint64
+}
+
+func (s *Stats) Hits() uint64 {
+	if s == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&s.hits)
+}
+
+func (s *Stats) Misses() uint64 {
+	if s == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&s.misses)
+}
+
+func (s *Stats) hit() {
+	if s != nil {
+		atomic.AddUint64(&s.hits, 1)
+	}
+}
+
+func (s *Stats) miss() {
+	if s != nil {
+		atomic.AddUint64(&s.misses, 1)
+	}
+}
+
+type PrepareFunc func(ctx context.Context, query string) (driver.Stmt, error)
+
+// New create LRU cache of prepared statements for one connection
+func New(size int, stats *Stats) *Cache {
+	return &Cache{
+		size:  size,
+		stats: stats,
+		lru:   list.New(),
+		items: map[string]*list.Element{},
+	}
+}
+
+type Cache struct {
+	size  int
+	stats *Stats
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+}
+
+type entry struct {
+	query string
+	stmt  driver.Stmt
+}
+
+// Get return the cached statement of query, or prepare and cache it.
+// the least recently used one will be closed when cache is full.
+func (c *Cache) Get(ctx context.Context, query string, prepare PrepareFunc) (driver.Stmt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[query]; ok {
+		c.lru.MoveToFront(elem)
+		c.stats.hit()
+		return elem.Value.(*entry).stmt, nil
+	}
+
+	c.stats.miss()
+
+	stmt, err := prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.items[query] = c.lru.PushFront(&entry{query: query, stmt: stmt})
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		e := oldest.Value.(*entry)
+		delete(c.items, e.query)
+		_ = e.stmt.Close()
+	}
+
+	return stmt, nil
+}
+
+// Evict remove the cached statement of query and close it
+func (c *Cache) Evict(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[query]; ok {
+		c.lru.Remove(elem)
+		delete(c.items, query)
+		_ = elem.Value.(*entry).stmt.Close()
+	}
+}
+
+// Exec exec by the cached statement of query, which will be evicted when failed
+func (c *Cache) Exec(ctx context.Context, query string, args []driver.NamedValue, prepare PrepareFunc) (driver.Result, error) {
+	stmt, err := c.Get(ctx, query, prepare)
+	if err != nil {
+		return nil, err
+	}
+	result, err := Exec(ctx, stmt, args)
+	if err != nil {
+		c.Evict(query)
+		return nil, err
+	}
+	return result, nil
+}
+
+// Query query by the cached statement of query, which will be evicted when failed
+func (c *Cache) Query(ctx context.Context, query string, args []driver.NamedValue, prepare PrepareFunc) (driver.Rows, error) {
+	stmt, err := c.Get(ctx, query, prepare)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := Query(ctx, stmt, args)
+	if err != nil {
+		c.Evict(query)
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// Close close all cached statements
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		if e := elem.Value.(*entry).stmt.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	c.lru.Init()
+	c.items = map[string]*list.Element{}
+
+	return err
+}
+
+// IsCacheable returns true only for DML (SELECT, INSERT, UPDATE, DELETE and WITH of them),
+// DDL, SAVEPOINT, DECLARE, FETCH and others should not be prepared and kept on connection.
+func IsCacheable(query string) bool {
+	switch strings.ToUpper(firstKeyword(query)) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "WITH":
+		return true
+	}
+	return false
+}
+
+// firstKeyword returns the first word of query, leading spaces, comments and brackets skipped
+func firstKeyword(query string) string {
+	for i := 0; i < len(query); i++ {
+		switch c := query[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(':
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end == -1 {
+				return ""
+			}
+			i += end + 3
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				return ""
+			}
+			i += end
+		default:
+			end := i
+			for end < len(query) && isLetter(query[end]) {
+				end++
+			}
+			return query[i:end]
+		}
+	}
+	return ""
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// Exec exec statement with context when supported
+func Exec(ctx context.Context, stmt driver.Stmt, args []driver.NamedValue) (driver.Result, error) {
+	if s, ok := stmt.(driver.StmtExecContext); ok {
+		return s.ExecContext(ctx, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return stmt.Exec(values)
+}
+
+// Query query statement with context when supported
+func Query(ctx context.Context, stmt driver.Stmt, args []driver.NamedValue) (driver.Rows, error) {
+	if s, ok := stmt.(driver.StmtQueryContext); ok {
+		return s.QueryContext(ctx, args)
+	}
+	values, err := namedValuesToValues(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return stmt.Query(values)
+}
+
+// NamedValues convert values of legacy Stmt.Exec and Stmt.Query to named values
+func NamedValues(values []driver.Value) []driver.NamedValue {
+	args := make([]driver.NamedValue, len(values))
+	for i := range values {
+		args[i] = driver.NamedValue{Ordinal: i + 1, Value: values[i]}
+	}
+	return args
+}
+
+func namedValuesToValues(named []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(named))
+	for i := range named {
+		if named[i].Name != "" {
+			return nil, driver.ErrSkip
+		}
+		values[i] = named[i].Value
+	}
+	return values, nil
+}
+
+// Prepare prepare statement with context when supported
+func Prepare(ctx context.Context, conn driver.Conn, query string) (driver.Stmt, error) {
+	if c, ok := conn.(driver.ConnPrepareContext); ok {
+		return c.PrepareContext(ctx, query)
+	}
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		_ = stmt.Close()
+		return nil, ctx.Err()
+	default:
+	}
+	return stmt, nil
+}
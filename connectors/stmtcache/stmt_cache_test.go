@@ -0,0 +1,128 @@
+package stmtcache
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+type fakeStmt struct {
+	driver.Stmt
+	closed bool
+}
+
+func (s *fakeStmt) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestCache(t *testing.T) {
+	stats := &Stats{}
+	c := New(2, stats)
+
+	stmts := map[string]*fakeStmt{}
+
+	prepare := func(ctx context.Context, query string) (driver.Stmt, error) {
+		s := &fakeStmt{}
+		stmts[query] = s
+		return s, nil
+	}
+
+	for _, query := range []string{"a", "b", "a", "c", "a", "b"} {
+		_, err := c.Get(context.Background(), query, prepare)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	}
+
+	gomega.NewWithT(t).Expect(stats.Hits()).To(gomega.Equal(uint64(2)))
+	gomega.NewWithT(t).Expect(stats.Misses()).To(gomega.Equal(uint64(4)))
+	gomega.NewWithT(t).Expect(c.Len()).To(gomega.Equal(2))
+	gomega.NewWithT(t).Expect(stmts["c"].closed).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(stmts["a"].closed).To(gomega.BeFalse())
+
+	err := c.Close()
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(c.Len()).To(gomega.Equal(0))
+	gomega.NewWithT(t).Expect(stmts["a"].closed).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(stmts["b"].closed).To(gomega.BeTrue())
+}
+
+type failedStmt struct {
+	fakeStmt
+}
+
+func (s *failedStmt) NumInput() int {
+	return -1
+}
+
+func (s *failedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec failed")
+}
+
+func (s *failedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("query failed")
+}
+
+func TestCacheEvict(t *testing.T) {
+	c := New(2, nil)
+
+	stmts := make([]*failedStmt, 0)
+
+	prepare := func(ctx context.Context, query string) (driver.Stmt, error) {
+		s := &failedStmt{}
+		stmts = append(stmts, s)
+		return s, nil
+	}
+
+	t.Run("evict when exec failed", func(t *testing.T) {
+		_, err := c.Exec(context.Background(), "UPDATE t SET f_a = 1", nil, prepare)
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(c.Len()).To(gomega.Equal(0))
+		gomega.NewWithT(t).Expect(stmts[len(stmts)-1].closed).To(gomega.BeTrue())
+	})
+
+	t.Run("evict when query failed", func(t *testing.T) {
+		_, err := c.Query(context.Background(), "SELECT * FROM t", nil, prepare)
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(c.Len()).To(gomega.Equal(0))
+		gomega.NewWithT(t).Expect(stmts[len(stmts)-1].closed).To(gomega.BeTrue())
+	})
+
+	t.Run("not cached when prepare failed", func(t *testing.T) {
+		_, err := c.Get(context.Background(), "SELECT * FROM t", func(ctx context.Context, query string) (driver.Stmt, error) {
+			return nil, errors.New("prepare failed")
+		})
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(c.Len()).To(gomega.Equal(0))
+	})
+}
+
+func TestIsCacheable(t *testing.T) {
+	cases := map[string]bool{
+		"SELECT * FROM t":                       true,
+		"select * from t":                       true,
+		"INSERT INTO t (f_a) VALUES (?)":        true,
+		"UPDATE t SET f_a = ?":                  true,
+		"DELETE FROM t":                         true,
+		"WITH x AS (SELECT 1) SELECT * FROM x":  true,
+		"(SELECT 1) UNION (SELECT 2)":           true,
+		"/* comment */ SELECT 1":                true,
+		"-- comment\nSELECT 1":                  true,
+		"CREATE TABLE t (f_a INTEGER)":          false,
+		"ALTER TABLE t ADD COLUMN f_b INTEGER":  false,
+		"DROP TABLE t":                          false,
+		"SAVEPOINT sp":                          false,
+		"RELEASE SAVEPOINT sp":                  false,
+		"DECLARE c CURSOR FOR SELECT * FROM t":  false,
+		"FETCH FORWARD 10 FROM c":               false,
+		"CLOSE c":                               false,
+		"/* SELECT */ CREATE INDEX i ON t(f_a)": false,
+		"":                                      false,
+	}
+
+	for query, cacheable := range cases {
+		gomega.NewWithT(t).Expect(IsCacheable(query)).To(gomega.Equal(cacheable), query)
+	}
+}
@@ -436,3 +436,117 @@ func TestSelect(t *testing.T) {
 		})
 	}
 }
+
+func TestPostgreSQLCursor(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_cursor")
+	db := dbTest.OpenDB(postgresConnector)
+	table := dbTest.Register(&User{})
+
+	db.Tables.Range(func(t *builder.Table, idx int) {
+		_, _ = db.ExecExpr(db.Dialect().DropTable(t))
+	})
+
+	err := migration.Migrate(db, nil)
+	NewWithT(t).Expect(err).To(BeNil())
+
+	{
+		columns := table.MustFields("Name", "Gender")
+		values := make([]interface{}, 0)
+
+		for i := 0; i < 250; i++ {
+			values = append(values, uuid.New().String(), GenderMale)
+		}
+
+		_, err := db.ExecExpr(builder.Insert().Into(table).Values(columns, values...))
+		NewWithT(t).Expect(err).To(BeNil())
+	}
+
+	t.Run("fetch in batches", func(t *testing.T) {
+		count := 0
+
+		err := sqlx.NewTasks(db).With(func(db sqlx.DBExecutor) error {
+			cursor, err := postgresqlconnector.DeclareCursor(db, "c_user", builder.Select(nil).From(table))
+			if err != nil {
+				return err
+			}
+			defer cursor.Close()
+
+			return cursor.Each(100, func(user *User) error {
+				count++
+				return nil
+			})
+		}).Do()
+
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(count).To(Equal(250))
+	})
+
+	t.Run("fetch to slice", func(t *testing.T) {
+		err := sqlx.NewTasks(db).With(func(db sqlx.DBExecutor) error {
+			cursor, err := postgresqlconnector.DeclareCursor(db, "c_user", builder.Select(nil).From(table))
+			if err != nil {
+				return err
+			}
+			defer cursor.Close()
+
+			users := make([]User, 0)
+			n, err := cursor.Fetch(200, &users)
+			NewWithT(t).Expect(err).To(BeNil())
+			NewWithT(t).Expect(n).To(Equal(200))
+			NewWithT(t).Expect(users).To(HaveLen(200))
+			return nil
+		}).Do()
+
+		NewWithT(t).Expect(err).To(BeNil())
+	})
+
+	t.Run("out of transaction", func(t *testing.T) {
+		_, err := postgresqlconnector.DeclareCursor(db, "c_user", builder.Select(nil).From(table))
+		NewWithT(t).Expect(err).To(Equal(sqlx.ErrNotTx))
+	})
+
+	db.Tables.Range(func(tab *builder.Table, idx int) {
+		_, _ = db.ExecExpr(db.Dialect().DropTable(tab))
+	})
+}
+
+func TestBulkInsert(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_bulk_insert")
+
+	for _, connector := range []driver.Connector{
+		mysqlConnector,
+		postgresConnector,
+	} {
+		t.Run("", func(t *testing.T) {
+			db := dbTest.OpenDB(connector)
+			table := dbTest.Register(&User{})
+
+			db.Tables.Range(func(t *builder.Table, idx int) {
+				_, _ = db.ExecExpr(db.Dialect().DropTable(t))
+			})
+
+			err := migration.Migrate(db, nil)
+			NewWithT(t).Expect(err).To(BeNil())
+
+			users := make([]*User, 0)
+			for i := 0; i < 1000; i++ {
+				user := &User{Name: uuid.New().String(), Gender: GenderMale}
+				user.CreatedAt = datatypes.MySQLDatetime(time.Now())
+				users = append(users, user)
+			}
+
+			n, err := sqlx.BulkInsert(db, table, sqlx.BulkRowsOf(users))
+			NewWithT(t).Expect(err).To(BeNil())
+			NewWithT(t).Expect(n).To(Equal(int64(1000)))
+
+			count := 0
+			err = db.QueryExprAndScan(builder.Select(builder.Count()).From(table), &count)
+			NewWithT(t).Expect(err).To(BeNil())
+			NewWithT(t).Expect(count).To(Equal(1000))
+
+			db.Tables.Range(func(tab *builder.Table, idx int) {
+				_, _ = db.ExecExpr(db.Dialect().DropTable(tab))
+			})
+		})
+	}
+}
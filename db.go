@@ -3,11 +3,13 @@ package sqlx
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/scanner"
 )
 
 var ErrNotTx = errors.New("db is not *sql.Tx")
@@ -50,6 +52,19 @@ type DBExecutor interface {
 	WithContext(ctx context.Context) DBExecutor
 }
 
+// SavepointDialect provides sql of savepoint for nested transaction
+type SavepointDialect interface {
+	Savepoint(name string) builder.SqlExpr
+	RollbackToSavepoint(name string) builder.SqlExpr
+	ReleaseSavepoint(name string) builder.SqlExpr
+}
+
+// MaxParamsDialect dialect with the limit of placeholders in one statement,
+// statement over the limit will be split into chunks by ExecExpr and QueryExprAndScan
+type MaxParamsDialect interface {
+	MaxParams() int
+}
+
 type MaybeTxExecutor interface {
 	IsTx() bool
 	BeginTx(*sql.TxOptions) (DBExecutor, error)
@@ -63,6 +78,13 @@ type DB struct {
 	*Database
 	SqlExecutor
 	ctx context.Context
+	// savepoint of nested transaction
+	savepoint      string
+	savepointDepth int
+	// hooks of transaction
+	hooks *txHooks
+	// interceptors of ExecExpr and QueryExpr
+	interceptors []Interceptor
 }
 
 func (d *DB) WithContext(ctx context.Context) DBExecutor {
@@ -99,41 +121,143 @@ func (d *DB) D() *Database {
 	return d.Database
 }
 
+// exprContext context with dialect for building expr
+func (d *DB) exprContext() context.Context {
+	return builder.ContextWithDialect(d.Context(), d.dialect)
+}
+
 func (d *DB) ExecExpr(expr builder.SqlExpr) (sql.Result, error) {
-	e := builder.ResolveExprContext(d.Context(), expr)
+	exprs, err := d.resolveExprInChunks(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(exprs) > 1 {
+		return d.execExprInChunks(exprs)
+	}
+	e := exprs[0]
 	if builder.IsNilExpr(e) {
 		return nil, nil
 	}
-	if err := e.Err(); err != nil {
-		return nil, err
-	}
-	result, err := d.ExecContext(d.Context(), e.Query(), e.Args()...)
+	result, err := d.execHandler()(d.Context(), e)
 	if err != nil {
-		if d.dialect.IsErrorConflict(err) {
-			return nil, NewSqlError(sqlErrTypeConflict, err.Error())
+		return nil, d.classifyError(err)
+	}
+	return result, nil
+}
+
+// execExprInChunks exec chunks in one transaction, and sum rows affected of them
+func (d *DB) execExprInChunks(exprs []*builder.Ex) (sql.Result, error) {
+	result := &chunkedResult{}
+
+	err := NewTasks(d).With(func(db DBExecutor) error {
+		for _, e := range exprs {
+			r, err := db.ExecExpr(e)
+			if err != nil {
+				return err
+			}
+			if err := result.add(r); err != nil {
+				return err
+			}
 		}
+		return nil
+	}).Do()
+
+	if err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
+// resolveExprInChunks resolve expr into chunks when args over the max params of dialect
+func (d *DB) resolveExprInChunks(expr builder.SqlExpr) ([]*builder.Ex, error) {
+	maxParams := 0
+	if maxParamsDialect, ok := d.dialect.(MaxParamsDialect); ok {
+		maxParams = maxParamsDialect.MaxParams()
+	}
+
+	exprs, err := builder.ResolveExprInChunks(d.exprContext(), expr, maxParams)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range exprs {
+		if builder.IsNilExpr(e) {
+			continue
+		}
+		if err := e.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return exprs, nil
+}
+
 func (d *DB) QueryExpr(expr builder.SqlExpr) (*sql.Rows, error) {
-	e := builder.ResolveExprContext(d.Context(), expr)
+	e := builder.ResolveExprContext(d.exprContext(), expr)
 	if builder.IsNilExpr(e) {
 		return nil, nil
 	}
 	if err := e.Err(); err != nil {
 		return nil, err
 	}
-	return d.QueryContext(d.Context(), e.Query(), e.Args()...)
+	rows, err := d.queryHandler()(d.Context(), e)
+	if err != nil {
+		return nil, d.classifyError(err)
+	}
+	return rows, nil
+}
+
+// classifyError wrap driver error as SqlError by dialect
+// and attach the violated unique key of registered tables when conflict
+func (d *DB) classifyError(err error) error {
+	err = classifyError(d.dialect, err)
+	if d.Database != nil {
+		resolveConflictKey(d.dialect, &d.Database.Tables, err)
+	}
+	return err
 }
 
 func (d *DB) QueryExprAndScan(expr builder.SqlExpr, v interface{}) error {
+	exprs, err := d.resolveExprInChunks(expr)
+	if err != nil {
+		return err
+	}
+	return d.classifyError(scanInChunks(d.Context(), exprs, d.QueryExpr, v))
+}
+
+// QueryExprCursor query and return cursor to scan rows one by one, cursor must be closed after used
+func (d *DB) QueryExprCursor(expr builder.SqlExpr) (*Cursor, error) {
 	rows, err := d.QueryExpr(expr)
 	if err != nil {
+		return nil, err
+	}
+	return scanner.NewCursor(d.Context(), rows), nil
+}
+
+// QueryExprEach query and call fn with each scanned row without buffering,
+// fn must be func(row T) error or func(row *T) error, T with slice fields of one-to-many relation is not supported,
+// return StopIteration in fn to stop the query.
+func (d *DB) QueryExprEach(expr builder.SqlExpr, fn interface{}) error {
+	// validate fn before querying, rows should not be opened for invalid fn
+	if _, err := scanner.ScanIteratorFromFunc(fn); err != nil {
 		return err
 	}
-	return Scan(rows, v)
+	rows, err := d.QueryExpr(expr)
+	if err != nil {
+		return err
+	}
+	return d.classifyError(scanner.Each(d.Context(), rows, fn))
+}
+
+// Prepare creates a prepared statement of the raw query, which could not be built by builder, like COPY
+func (d *DB) Prepare(query string) (*sql.Stmt, error) {
+	preparer, ok := d.SqlExecutor.(interface {
+		PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("%T could not prepare statement", d.SqlExecutor)
+	}
+	return preparer.PrepareContext(d.Context(), query)
 }
 
 func (d *DB) IsTx() bool {
@@ -147,20 +271,52 @@ func (d *DB) Begin() (DBExecutor, error) {
 
 func (d *DB) BeginTx(opt *sql.TxOptions) (DBExecutor, error) {
 	if d.IsTx() {
-		return nil, ErrNotDB
+		return d.beginSavepoint()
 	}
 	db, err := d.SqlExecutor.(*sql.DB).BeginTx(d.Context(), opt)
 	if err != nil {
 		return nil, err
 	}
 	return &DB{
-		Database:    d.Database,
-		dialect:     d.dialect,
-		SqlExecutor: db,
-		ctx:         d.Context(),
+		Database:     d.Database,
+		dialect:      d.dialect,
+		SqlExecutor:  db,
+		ctx:          d.Context(),
+		hooks:        &txHooks{},
+		interceptors: d.interceptors,
+	}, nil
+}
+
+func (d *DB) beginSavepoint() (DBExecutor, error) {
+	savepointDialect, ok := d.dialect.(SavepointDialect)
+	if !ok {
+		return nil, ErrNotDB
+	}
+
+	depth := d.savepointDepth + 1
+	name := fmt.Sprintf("sp_%d", depth)
+
+	if _, err := d.ExecExpr(savepointDialect.Savepoint(name)); err != nil {
+		return nil, err
+	}
+
+	return &DB{
+		Database:       d.Database,
+		dialect:        d.dialect,
+		SqlExecutor:    d.SqlExecutor,
+		ctx:            d.Context(),
+		savepoint:      name,
+		savepointDepth: depth,
+		hooks:          &txHooks{parent: d.hooks},
+		interceptors:   d.interceptors,
 	}, nil
 }
 
+// IsSavepoint return true when db is a nested transaction created in tx
+func (d *DB) IsSavepoint() bool {
+	return d.savepoint != ""
+}
+
 func (d *DB) Commit() error {
 	if !d.IsTx() {
 		return ErrNotTx
@@ -168,7 +324,18 @@ func (d *DB) Commit() error {
 	if d.Context().Err() == context.Canceled {
 		return context.Canceled
 	}
-	return d.SqlExecutor.(*sql.Tx).Commit()
+	if d.IsSavepoint() {
+		if _, err := d.ExecExpr(d.dialect.(SavepointDialect).ReleaseSavepoint(d.savepoint)); err != nil {
+			return err
+		}
+		d.hooks.bubble()
+		return nil
+	}
+	if err := d.SqlExecutor.(*sql.Tx).Commit(); err != nil {
+		return err
+	}
+	d.hooks.committed(d.Context())
+	return nil
 }
 
 func (d *DB) Rollback() error {
@@ -178,7 +345,18 @@ func (d *DB) Rollback() error {
 	if d.Context().Err() == context.Canceled {
 		return context.Canceled
 	}
-	return d.SqlExecutor.(*sql.Tx).Rollback()
+	if d.IsSavepoint() {
+		if _, err := d.ExecExpr(d.dialect.(SavepointDialect).RollbackToSavepoint(d.savepoint)); err != nil {
+			return err
+		}
+		d.hooks.rolledBack(d.Context())
+		return nil
+	}
+	if err := d.SqlExecutor.(*sql.Tx).Rollback(); err != nil {
+		return err
+	}
+	d.hooks.rolledBack(d.Context())
+	return nil
 }
 
 func (d *DB) SetMaxOpenConns(n int) {
@@ -1,9 +1,12 @@
 package sqlx_test
 
 import (
+	"database/sql"
 	"testing"
 
 	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/onsi/gomega"
 )
 
 func BenchmarkDB_DBExecutor(b *testing.B) {
@@ -18,3 +21,56 @@ func BenchmarkDB_DBExecutor(b *testing.B) {
 		run(db)
 	}
 }
+
+func TestQueryExprEach(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_each", &Member{})
+	db.SetMaxOpenConns(1)
+
+	insertMembers(t, db, "a0", "a1", "a2", "a3", "a4")
+
+	table := db.T(&Member{})
+
+	t.Run("each", func(t *testing.T) {
+		names := make([]string, 0)
+
+		err := db.QueryExprEach(builder.Select(nil).From(table, builder.OrderBy(builder.AscOrder(table.F("ID")))), func(m *Member) error {
+			names = append(names, m.Name)
+			if len(names) == 3 {
+				return sqlx.StopIteration
+			}
+			return nil
+		})
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(names).To(Equal([]string{"a0", "a1", "a2"}))
+	})
+
+	t.Run("each with bad fn", func(t *testing.T) {
+		err := db.QueryExprEach(builder.Select(nil).From(table), func(m Member) {})
+		NewWithT(t).Expect(err).NotTo(BeNil())
+		NewWithT(t).Expect(db.SqlExecutor.(*sql.DB).Stats().InUse).To(Equal(0))
+
+		rwdb := &sqlx.RWDB{DB: db}
+
+		err = rwdb.QueryExprEach(builder.Select(nil).From(table), 1)
+		NewWithT(t).Expect(err).NotTo(BeNil())
+		NewWithT(t).Expect(db.SqlExecutor.(*sql.DB).Stats().InUse).To(Equal(0))
+
+		// the only connection should be released
+		NewWithT(t).Expect(queryMemberNames(t, db)).To(HaveLen(5))
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		c, err := db.QueryExprCursor(builder.Select(nil).From(table))
+		NewWithT(t).Expect(err).To(BeNil())
+		defer c.Close()
+
+		count := 0
+		for c.Next() {
+			m := Member{}
+			NewWithT(t).Expect(c.Scan(&m)).To(BeNil())
+			count++
+		}
+		NewWithT(t).Expect(c.Err()).To(BeNil())
+		NewWithT(t).Expect(count).To(Equal(5))
+	})
+}
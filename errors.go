@@ -1,32 +1,162 @@
 package sqlx
 
 import (
+	"context"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+
+	"github.com/kunlun-qilian/sqlx/v3/builder"
 )
 
-func NewSqlError(tpe sqlErrType, msg string) *SqlError {
+func NewSqlError(tpe SqlErrType, msg string) *SqlError {
 	return &SqlError{
 		Type: tpe,
 		Msg:  msg,
 	}
 }
 
+// WrapSqlError wrap err as SqlError with type, and keep err as the cause
+func WrapSqlError(tpe SqlErrType, err error) *SqlError {
+	return &SqlError{
+		Type: tpe,
+		Msg:  err.Error(),
+		err:  err,
+	}
+}
+
 type SqlError struct {
-	Type sqlErrType
+	Type SqlErrType
 	Msg  string
+
+	err error
+	// unique key violated when conflict
+	conflictKey *builder.Key
 }
 
 func (e *SqlError) Error() string {
 	return fmt.Sprintf("Sqlx [%s] %s", e.Type, e.Msg)
 }
 
-type sqlErrType string
+// Unwrap returns the driver error
+func (e *SqlError) Unwrap() error {
+	return e.err
+}
+
+// Is matches SqlError with same type, like errors.Is(err, sqlx.ErrDeadlock)
+func (e *SqlError) Is(target error) bool {
+	if t, ok := target.(*SqlError); ok {
+		return t.Type == e.Type
+	}
+	return false
+}
+
+// ConflictKey returns the violated unique key of the registered table when conflict,
+// nil when could not be resolved.
+func (e *SqlError) ConflictKey() *builder.Key {
+	return e.conflictKey
+}
+
+// ConflictFields returns struct field names of the violated unique key when conflict
+func (e *SqlError) ConflictFields() []string {
+	if e.conflictKey == nil {
+		return nil
+	}
+	return e.conflictKey.FieldNames()
+}
+
+type SqlErrType string
+
+const (
+	SqlErrTypeNotFound            SqlErrType = "NotFound"
+	SqlErrTypeConflict            SqlErrType = "Conflict"
+	SqlErrTypeForeignKeyViolation SqlErrType = "ForeignKeyViolation"
+	SqlErrTypeNotNullViolation    SqlErrType = "NotNullViolation"
+	SqlErrTypeCheckViolation      SqlErrType = "CheckViolation"
+	SqlErrTypeValueTooLong        SqlErrType = "ValueTooLong"
+	SqlErrTypeDeadlock            SqlErrType = "Deadlock"
+	SqlErrTypeLockTimeout         SqlErrType = "LockTimeout"
+	SqlErrTypeCanceled            SqlErrType = "Canceled"
+	SqlErrTypeConnectionLost      SqlErrType = "ConnectionLost"
+)
 
+// errors for errors.Is
 var (
-	sqlErrTypeNotFound sqlErrType = "NotFound"
-	sqlErrTypeConflict sqlErrType = "Conflict"
+	ErrNotFound            = NewSqlError(SqlErrTypeNotFound, "record is not found")
+	ErrConflict            = NewSqlError(SqlErrTypeConflict, "conflict")
+	ErrForeignKeyViolation = NewSqlError(SqlErrTypeForeignKeyViolation, "foreign key violation")
+	ErrNotNullViolation    = NewSqlError(SqlErrTypeNotNullViolation, "not null violation")
+	ErrCheckViolation      = NewSqlError(SqlErrTypeCheckViolation, "check violation")
+	ErrValueTooLong        = NewSqlError(SqlErrTypeValueTooLong, "value too long")
+	ErrDeadlock            = NewSqlError(SqlErrTypeDeadlock, "deadlock")
+	ErrLockTimeout         = NewSqlError(SqlErrTypeLockTimeout, "lock timeout")
+	ErrCanceled            = NewSqlError(SqlErrTypeCanceled, "canceled")
+	ErrConnectionLost      = NewSqlError(SqlErrTypeConnectionLost, "connection lost")
 )
 
+// ErrorClassifierDialect dialect which classifies driver errors
+type ErrorClassifierDialect interface {
+	// ClassifyError returns type of the driver error, empty when unknown
+	ClassifyError(err error) SqlErrType
+}
+
+// classifyError wrap driver error as SqlError when could be classified
+func classifyError(dialect builder.Dialect, err error) error {
+	if err == nil || sqlErrorOf(err) != nil {
+		return err
+	}
+
+	tpe := SqlErrType("")
+
+	if classifier, ok := dialect.(ErrorClassifierDialect); ok {
+		tpe = classifier.ClassifyError(err)
+	}
+
+	if tpe == "" {
+		switch {
+		case dialect.IsErrorConflict(err):
+			tpe = SqlErrTypeConflict
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			tpe = SqlErrTypeCanceled
+		case errors.Is(err, driver.ErrBadConn):
+			tpe = SqlErrTypeConnectionLost
+		}
+	}
+
+	if tpe == "" {
+		return err
+	}
+
+	return WrapSqlError(tpe, err)
+}
+
+// ConflictKeyDialect dialect which resolves the violated unique key of conflict error
+type ConflictKeyDialect interface {
+	// ConflictKey returns the key of tables violated by the driver error, nil when unknown
+	ConflictKey(err error, tables *builder.Tables) *builder.Key
+}
+
+// resolveConflictKey attach the violated unique key to conflict error
+func resolveConflictKey(dialect builder.Dialect, tables *builder.Tables, err error) {
+	sqlErr, ok := err.(*SqlError)
+	if !ok || sqlErr.Type != SqlErrTypeConflict || sqlErr.err == nil || sqlErr.conflictKey != nil {
+		return
+	}
+	if resolver, ok := dialect.(ConflictKeyDialect); ok {
+		sqlErr.conflictKey = resolver.ConflictKey(sqlErr.err, tables)
+	}
+}
+
+func sqlErrorOf(err error) *SqlError {
+	for err != nil {
+		if sqlErr, ok := err.(*SqlError); ok {
+			return sqlErr
+		}
+		err = UnwrapOnce(err)
+	}
+	return nil
+}
+
 var DuplicateEntryErrNumber uint16 = 1062
 
 func DBErr(err error) *dbErr {
@@ -38,54 +168,127 @@ func DBErr(err error) *dbErr {
 type dbErr struct {
 	err error
 
-	errDefault  error
-	errNotFound error
-	errConflict error
+	errDefault error
+	errs       map[SqlErrType]error
 }
 
-func (r dbErr) WithNotFound(err error) *dbErr {
-	r.errNotFound = err
+func (r dbErr) with(tpe SqlErrType, err error) *dbErr {
+	errs := make(map[SqlErrType]error, len(r.errs)+1)
+	for t, e := range r.errs {
+		errs[t] = e
+	}
+	errs[tpe] = err
+	r.errs = errs
 	return &r
 }
 
+func (r *dbErr) is(tpe SqlErrType) bool {
+	if sqlErr := sqlErrorOf(r.err); sqlErr != nil {
+		return sqlErr.Type == tpe
+	}
+	return false
+}
+
 func (r dbErr) WithDefault(err error) *dbErr {
 	r.errDefault = err
 	return &r
 }
 
+func (r dbErr) WithNotFound(err error) *dbErr {
+	return r.with(SqlErrTypeNotFound, err)
+}
+
 func (r dbErr) WithConflict(err error) *dbErr {
-	r.errConflict = err
-	return &r
+	return r.with(SqlErrTypeConflict, err)
+}
+
+func (r dbErr) WithForeignKeyViolation(err error) *dbErr {
+	return r.with(SqlErrTypeForeignKeyViolation, err)
+}
+
+func (r dbErr) WithNotNullViolation(err error) *dbErr {
+	return r.with(SqlErrTypeNotNullViolation, err)
+}
+
+func (r dbErr) WithCheckViolation(err error) *dbErr {
+	return r.with(SqlErrTypeCheckViolation, err)
+}
+
+func (r dbErr) WithValueTooLong(err error) *dbErr {
+	return r.with(SqlErrTypeValueTooLong, err)
+}
+
+func (r dbErr) WithDeadlock(err error) *dbErr {
+	return r.with(SqlErrTypeDeadlock, err)
+}
+
+func (r dbErr) WithLockTimeout(err error) *dbErr {
+	return r.with(SqlErrTypeLockTimeout, err)
+}
+
+func (r dbErr) WithCanceled(err error) *dbErr {
+	return r.with(SqlErrTypeCanceled, err)
+}
+
+func (r dbErr) WithConnectionLost(err error) *dbErr {
+	return r.with(SqlErrTypeConnectionLost, err)
 }
 
 func (r *dbErr) IsNotFound() bool {
-	if sqlErr, ok := UnwrapAll(r.err).(*SqlError); ok {
-		return sqlErr.Type == sqlErrTypeNotFound
-	}
-	return false
+	return r.is(SqlErrTypeNotFound)
 }
 
 func (r *dbErr) IsConflict() bool {
-	if sqlErr, ok := UnwrapAll(r.err).(*SqlError); ok {
-		return sqlErr.Type == sqlErrTypeConflict
+	return r.is(SqlErrTypeConflict)
+}
+
+func (r *dbErr) IsForeignKeyViolation() bool {
+	return r.is(SqlErrTypeForeignKeyViolation)
+}
+
+func (r *dbErr) IsNotNullViolation() bool {
+	return r.is(SqlErrTypeNotNullViolation)
+}
+
+func (r *dbErr) IsCheckViolation() bool {
+	return r.is(SqlErrTypeCheckViolation)
+}
+
+func (r *dbErr) IsValueTooLong() bool {
+	return r.is(SqlErrTypeValueTooLong)
+}
+
+func (r *dbErr) IsDeadlock() bool {
+	return r.is(SqlErrTypeDeadlock)
+}
+
+func (r *dbErr) IsLockTimeout() bool {
+	return r.is(SqlErrTypeLockTimeout)
+}
+
+func (r *dbErr) IsCanceled() bool {
+	return r.is(SqlErrTypeCanceled)
+}
+
+func (r *dbErr) IsConnectionLost() bool {
+	return r.is(SqlErrTypeConnectionLost)
+}
+
+// ConflictFields returns struct field names of the violated unique key when conflict
+func (r *dbErr) ConflictFields() []string {
+	if sqlErr := sqlErrorOf(r.err); sqlErr != nil {
+		return sqlErr.ConflictFields()
 	}
-	return false
+	return nil
 }
 
 func (r *dbErr) Err() error {
 	if r.err == nil {
 		return nil
 	}
-	if sqlErr, ok := UnwrapAll(r.err).(*SqlError); ok {
-		switch sqlErr.Type {
-		case sqlErrTypeNotFound:
-			if r.errNotFound != nil {
-				return r.errNotFound
-			}
-		case sqlErrTypeConflict:
-			if r.errConflict != nil {
-				return r.errConflict
-			}
+	if sqlErr := sqlErrorOf(r.err); sqlErr != nil {
+		if err, ok := r.errs[sqlErr.Type]; ok && err != nil {
+			return err
 		}
 		if r.errDefault != nil {
 			return r.errDefault
@@ -0,0 +1,83 @@
+package sqlx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/onsi/gomega"
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestSqlError(t *testing.T) {
+	cause := errors.New("deadlock detected")
+	err := pkgerrors.Wrap(sqlx.WrapSqlError(sqlx.SqlErrTypeDeadlock, cause), "update failed")
+
+	t.Run("errors.Is", func(t *testing.T) {
+		NewWithT(t).Expect(errors.Is(err, sqlx.ErrDeadlock)).To(BeTrue())
+		NewWithT(t).Expect(errors.Is(err, sqlx.ErrConflict)).To(BeFalse())
+		NewWithT(t).Expect(errors.Is(err, cause)).To(BeTrue())
+	})
+
+	t.Run("errors.As", func(t *testing.T) {
+		sqlErr := &sqlx.SqlError{}
+		NewWithT(t).Expect(errors.As(err, &sqlErr)).To(BeTrue())
+		NewWithT(t).Expect(sqlErr.Type).To(Equal(sqlx.SqlErrTypeDeadlock))
+	})
+
+	t.Run("UnwrapAll stops at SqlError", func(t *testing.T) {
+		sqlErr, ok := sqlx.UnwrapAll(err).(*sqlx.SqlError)
+		NewWithT(t).Expect(ok).To(BeTrue())
+		NewWithT(t).Expect(sqlErr.Unwrap()).To(Equal(cause))
+	})
+
+	t.Run("DBErr", func(t *testing.T) {
+		NewWithT(t).Expect(sqlx.DBErr(err).IsDeadlock()).To(BeTrue())
+		NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(BeFalse())
+
+		errRetry := errors.New("retry later")
+
+		NewWithT(t).Expect(
+			sqlx.DBErr(err).
+				WithConflict(errors.New("conflict")).
+				WithDeadlock(errRetry).
+				Err(),
+		).To(Equal(errRetry))
+
+		NewWithT(t).Expect(sqlx.DBErr(cause).WithDeadlock(errRetry).Err()).To(Equal(cause))
+	})
+}
+
+func TestDBClassifyError(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_classify_error", &Member{})
+
+	insertMembers(t, db, "a")
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "a"}, nil))
+		NewWithT(t).Expect(errors.Is(err, sqlx.ErrConflict)).To(BeTrue())
+		NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(BeTrue())
+		NewWithT(t).Expect(db.Dialect().IsErrorConflict(err)).To(BeTrue())
+
+		sqlErr, ok := sqlx.UnwrapAll(err).(*sqlx.SqlError)
+		NewWithT(t).Expect(ok).To(BeTrue())
+		NewWithT(t).Expect(sqlErr.Type).To(Equal(sqlx.SqlErrTypeConflict))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		m := Member{}
+		err := db.QueryExprAndScan(builder.Select(nil).From(db.T(&Member{}), builder.Where(db.T(&Member{}).F("Name").Eq("b"))), &m)
+		NewWithT(t).Expect(sqlx.DBErr(err).IsNotFound()).To(BeTrue())
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := db.WithContext(ctx).QueryExprAndScan(builder.Select(nil).From(db.T(&Member{})), &[]Member{})
+		NewWithT(t).Expect(errors.Is(err, sqlx.ErrCanceled)).To(BeTrue())
+		NewWithT(t).Expect(sqlx.DBErr(err).IsCanceled()).To(BeTrue())
+	})
+}
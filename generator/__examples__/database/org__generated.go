@@ -3,8 +3,8 @@ package database
 import (
 	fmt "fmt"
 
-	github_com_go_courier_sqlx_v2 "github.com/kunlun-qilian/sqlx/v3"
-	github_com_go_courier_sqlx_v2_builder "github.com/kunlun-qilian/sqlx/v3/builder"
+	github_com_kunlun_qilian_sqlx_v3 "github.com/kunlun-qilian/sqlx/v3"
+	github_com_kunlun_qilian_sqlx_v3_builder "github.com/kunlun-qilian/sqlx/v3/builder"
 )
 
 func (Org) PrimaryKey() []string {
@@ -19,7 +19,7 @@ func (Org) Comments() map[string]string {
 	}
 }
 
-var OrgTable *github_com_go_courier_sqlx_v2_builder.Table
+var OrgTable *github_com_kunlun_qilian_sqlx_v3_builder.Table
 
 func init() {
 	OrgTable = DBTest.Register(&Org{})
@@ -59,7 +59,7 @@ func (Org) FieldKeyID() string {
 	return "ID"
 }
 
-func (m *Org) FieldID() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *Org) FieldID() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return OrgTable.F(m.FieldKeyID())
 }
 
@@ -67,7 +67,7 @@ func (Org) FieldKeyName() string {
 	return "Name"
 }
 
-func (m *Org) FieldName() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *Org) FieldName() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return OrgTable.F(m.FieldKeyName())
 }
 
@@ -75,7 +75,7 @@ func (Org) FieldKeyUserID() string {
 	return "UserID"
 }
 
-func (m *Org) FieldUserID() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *Org) FieldUserID() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return OrgTable.F(m.FieldKeyUserID())
 }
 
@@ -94,11 +94,11 @@ func (m *Org) IndexFieldNames() []string {
 	}
 }
 
-func (m *Org) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) github_com_go_courier_sqlx_v2_builder.SqlCondition {
+func (m *Org) ConditionByStruct(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition {
 	table := db.T(m)
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m)
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValuesFromStructByNonZero(m)
 
-	conditions := make([]github_com_go_courier_sqlx_v2_builder.SqlCondition, 0)
+	conditions := make([]github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, 0)
 
 	for _, fieldName := range m.IndexFieldNames() {
 		if v, exists := fieldValues[fieldName]; exists {
@@ -115,44 +115,44 @@ func (m *Org) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) git
 		conditions = append(conditions, table.F(fieldName).Eq(v))
 	}
 
-	condition := github_com_go_courier_sqlx_v2_builder.And(conditions...)
+	condition := github_com_kunlun_qilian_sqlx_v3_builder.And(conditions...)
 
 	return condition
 }
 
-func (m *Org) Create(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *Org) Create(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
-	_, err := db.ExecExpr(github_com_go_courier_sqlx_v2.InsertToDB(db, m, nil))
+	_, err := db.ExecExpr(github_com_kunlun_qilian_sqlx_v3.InsertToDB(db, m, nil))
 	return err
 
 }
 
-func (m *Org) DeleteByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *Org) DeleteByStruct(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Delete().
+		github_com_kunlun_qilian_sqlx_v3_builder.Delete().
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(m.ConditionByStruct(db)),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.DeleteByStruct"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(m.ConditionByStruct(db)),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.DeleteByStruct"),
 			),
 	)
 
 	return err
 }
 
-func (m *Org) FetchByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *Org) FetchByID(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 				)),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.FetchByID"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.FetchByID"),
 			),
 		m,
 	)
@@ -160,17 +160,17 @@ func (m *Org) FetchByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 	return err
 }
 
-func (m *Org) UpdateByIDWithMap(db github_com_go_courier_sqlx_v2.DBExecutor, fieldValues github_com_go_courier_sqlx_v2_builder.FieldValues) error {
+func (m *Org) UpdateByIDWithMap(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, fieldValues github_com_kunlun_qilian_sqlx_v3_builder.FieldValues) error {
 
 	table := db.T(m)
 
 	result, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+		github_com_kunlun_qilian_sqlx_v3_builder.Update(db.T(m)).
 			Where(
-				github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 				),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.UpdateByIDWithMap"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.UpdateByIDWithMap"),
 			).
 			Set(table.AssignmentsByFieldValues(fieldValues)...),
 	)
@@ -188,26 +188,26 @@ func (m *Org) UpdateByIDWithMap(db github_com_go_courier_sqlx_v2.DBExecutor, fie
 
 }
 
-func (m *Org) UpdateByIDWithStruct(db github_com_go_courier_sqlx_v2.DBExecutor, zeroFields ...string) error {
+func (m *Org) UpdateByIDWithStruct(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, zeroFields ...string) error {
 
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
 	return m.UpdateByIDWithMap(db, fieldValues)
 
 }
 
-func (m *Org) FetchByIDForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *Org) FetchByIDForUpdate(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 				)),
-				github_com_go_courier_sqlx_v2_builder.ForUpdate(),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.FetchByIDForUpdate"),
+				github_com_kunlun_qilian_sqlx_v3_builder.ForUpdate(),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.FetchByIDForUpdate"),
 			),
 		m,
 	)
@@ -215,32 +215,32 @@ func (m *Org) FetchByIDForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) er
 	return err
 }
 
-func (m *Org) DeleteByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *Org) DeleteByID(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Delete().
+		github_com_kunlun_qilian_sqlx_v3_builder.Delete().
 			From(db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 				)),
-				github_com_go_courier_sqlx_v2_builder.Comment("Org.DeleteByID"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.DeleteByID"),
 			))
 
 	return err
 }
 
-func (m *Org) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]Org, error) {
+func (m *Org) List(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, condition github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, additions ...github_com_kunlun_qilian_sqlx_v3_builder.Addition) ([]Org, error) {
 
 	list := make([]Org, 0)
 
 	table := db.T(m)
 	_ = table
 
-	finalAdditions := []github_com_go_courier_sqlx_v2_builder.Addition{
-		github_com_go_courier_sqlx_v2_builder.Where(condition),
-		github_com_go_courier_sqlx_v2_builder.Comment("Org.List"),
+	finalAdditions := []github_com_kunlun_qilian_sqlx_v3_builder.Addition{
+		github_com_kunlun_qilian_sqlx_v3_builder.Where(condition),
+		github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.List"),
 	}
 
 	if len(additions) > 0 {
@@ -248,7 +248,7 @@ func (m *Org) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition github
 	}
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(db.T(m), finalAdditions...),
 		&list,
 	)
@@ -257,16 +257,16 @@ func (m *Org) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition github
 
 }
 
-func (m *Org) Count(db github_com_go_courier_sqlx_v2.DBExecutor, condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error) {
+func (m *Org) Count(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, condition github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, additions ...github_com_kunlun_qilian_sqlx_v3_builder.Addition) (int, error) {
 
 	count := -1
 
 	table := db.T(m)
 	_ = table
 
-	finalAdditions := []github_com_go_courier_sqlx_v2_builder.Addition{
-		github_com_go_courier_sqlx_v2_builder.Where(condition),
-		github_com_go_courier_sqlx_v2_builder.Comment("Org.Count"),
+	finalAdditions := []github_com_kunlun_qilian_sqlx_v3_builder.Addition{
+		github_com_kunlun_qilian_sqlx_v3_builder.Where(condition),
+		github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.Count"),
 	}
 
 	if len(additions) > 0 {
@@ -274,8 +274,8 @@ func (m *Org) Count(db github_com_go_courier_sqlx_v2.DBExecutor, condition githu
 	}
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(
-			github_com_go_courier_sqlx_v2_builder.Count(),
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(
+			github_com_kunlun_qilian_sqlx_v3_builder.Count(),
 		).
 			From(db.T(m), finalAdditions...),
 		&count,
@@ -285,7 +285,35 @@ func (m *Org) Count(db github_com_go_courier_sqlx_v2.DBExecutor, condition githu
 
 }
 
-func (m *Org) BatchFetchByIDList(db github_com_go_courier_sqlx_v2.DBExecutor, values []uint64) ([]Org, error) {
+func (m *Org) Page(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, condition github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, page github_com_kunlun_qilian_sqlx_v3.Page, additions ...github_com_kunlun_qilian_sqlx_v3_builder.Addition) ([]Org, *github_com_kunlun_qilian_sqlx_v3.PageInfo, error) {
+
+	list := make([]Org, 0)
+
+	table := db.T(m)
+	_ = table
+
+	finalAdditions := []github_com_kunlun_qilian_sqlx_v3_builder.Addition{
+		github_com_kunlun_qilian_sqlx_v3_builder.Where(condition),
+		github_com_kunlun_qilian_sqlx_v3_builder.Comment("Org.Page"),
+	}
+
+	if len(additions) > 0 {
+		finalAdditions = append(finalAdditions, additions...)
+	}
+
+	info, err := github_com_kunlun_qilian_sqlx_v3.Paginate(
+		db,
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
+			From(db.T(m), finalAdditions...),
+		page,
+		&list,
+	)
+
+	return list, info, err
+
+}
+
+func (m *Org) BatchFetchByIDList(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, values []uint64) ([]Org, error) {
 
 	if len(values) == 0 {
 		return nil, nil
@@ -4,9 +4,9 @@ import (
 	fmt "fmt"
 	time "time"
 
-	github_com_go_courier_sqlx_v2 "github.com/kunlun-qilian/sqlx/v3"
-	github_com_go_courier_sqlx_v2_builder "github.com/kunlun-qilian/sqlx/v3/builder"
-	github_com_go_courier_sqlx_v2_datatypes "github.com/kunlun-qilian/sqlx/v3/datatypes"
+	github_com_kunlun_qilian_sqlx_v3 "github.com/kunlun-qilian/sqlx/v3"
+	github_com_kunlun_qilian_sqlx_v3_builder "github.com/kunlun-qilian/sqlx/v3/builder"
+	github_com_kunlun_qilian_sqlx_v3_datatypes "github.com/kunlun-qilian/sqlx/v3/datatypes"
 )
 
 func (User) PrimaryKey() []string {
@@ -15,8 +15,8 @@ func (User) PrimaryKey() []string {
 	}
 }
 
-func (User) Indexes() github_com_go_courier_sqlx_v2_builder.Indexes {
-	return github_com_go_courier_sqlx_v2_builder.Indexes{
+func (User) Indexes() github_com_kunlun_qilian_sqlx_v3_builder.Indexes {
+	return github_com_kunlun_qilian_sqlx_v3_builder.Indexes{
 		"i_geom/SPATIAL": []string{
 			"(#Geom)",
 		},
@@ -33,8 +33,8 @@ func (User) UniqueIndexIName() string {
 	return "i_name"
 }
 
-func (User) UniqueIndexes() github_com_go_courier_sqlx_v2_builder.Indexes {
-	return github_com_go_courier_sqlx_v2_builder.Indexes{
+func (User) UniqueIndexes() github_com_kunlun_qilian_sqlx_v3_builder.Indexes {
+	return github_com_kunlun_qilian_sqlx_v3_builder.Indexes{
 		"i_name": []string{
 			"Name",
 			"DeletedAt",
@@ -48,7 +48,7 @@ func (User) Comments() map[string]string {
 	}
 }
 
-var UserTable *github_com_go_courier_sqlx_v2_builder.Table
+var UserTable *github_com_kunlun_qilian_sqlx_v3_builder.Table
 
 func init() {
 	UserTable = DBTest.Register(&User{})
@@ -81,7 +81,7 @@ func (User) FieldKeyID() string {
 	return "ID"
 }
 
-func (m *User) FieldID() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldID() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyID())
 }
 
@@ -89,7 +89,7 @@ func (User) FieldKeyName() string {
 	return "Name"
 }
 
-func (m *User) FieldName() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldName() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyName())
 }
 
@@ -97,7 +97,7 @@ func (User) FieldKeyUsername() string {
 	return "Username"
 }
 
-func (m *User) FieldUsername() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldUsername() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyUsername())
 }
 
@@ -105,7 +105,7 @@ func (User) FieldKeyNickname() string {
 	return "Nickname"
 }
 
-func (m *User) FieldNickname() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldNickname() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyNickname())
 }
 
@@ -113,7 +113,7 @@ func (User) FieldKeyGender() string {
 	return "Gender"
 }
 
-func (m *User) FieldGender() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldGender() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyGender())
 }
 
@@ -121,7 +121,7 @@ func (User) FieldKeyBoolean() string {
 	return "Boolean"
 }
 
-func (m *User) FieldBoolean() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldBoolean() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyBoolean())
 }
 
@@ -129,7 +129,7 @@ func (User) FieldKeyGeom() string {
 	return "Geom"
 }
 
-func (m *User) FieldGeom() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldGeom() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyGeom())
 }
 
@@ -137,7 +137,7 @@ func (User) FieldKeyCreatedAt() string {
 	return "CreatedAt"
 }
 
-func (m *User) FieldCreatedAt() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldCreatedAt() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyCreatedAt())
 }
 
@@ -145,7 +145,7 @@ func (User) FieldKeyUpdatedAt() string {
 	return "UpdatedAt"
 }
 
-func (m *User) FieldUpdatedAt() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldUpdatedAt() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyUpdatedAt())
 }
 
@@ -153,7 +153,7 @@ func (User) FieldKeyDeletedAt() string {
 	return "DeletedAt"
 }
 
-func (m *User) FieldDeletedAt() *github_com_go_courier_sqlx_v2_builder.Column {
+func (m *User) FieldDeletedAt() *github_com_kunlun_qilian_sqlx_v3_builder.Column {
 	return UserTable.F(m.FieldKeyDeletedAt())
 }
 
@@ -170,11 +170,11 @@ func (m *User) IndexFieldNames() []string {
 	}
 }
 
-func (m *User) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) github_com_go_courier_sqlx_v2_builder.SqlCondition {
+func (m *User) ConditionByStruct(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition {
 	table := db.T(m)
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m)
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValuesFromStructByNonZero(m)
 
-	conditions := make([]github_com_go_courier_sqlx_v2_builder.SqlCondition, 0)
+	conditions := make([]github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, 0)
 
 	for _, fieldName := range m.IndexFieldNames() {
 		if v, exists := fieldValues[fieldName]; exists {
@@ -191,42 +191,42 @@ func (m *User) ConditionByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) gi
 		conditions = append(conditions, table.F(fieldName).Eq(v))
 	}
 
-	condition := github_com_go_courier_sqlx_v2_builder.And(conditions...)
+	condition := github_com_kunlun_qilian_sqlx_v3_builder.And(conditions...)
 
-	condition = github_com_go_courier_sqlx_v2_builder.And(condition, table.F("DeletedAt").Eq(0))
+	condition = github_com_kunlun_qilian_sqlx_v3_builder.And(condition, table.F("DeletedAt").Eq(0))
 	return condition
 }
 
-func (m *User) Create(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) Create(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	if m.CreatedAt.IsZero() {
-		m.CreatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		m.CreatedAt = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	if m.UpdatedAt.IsZero() {
-		m.UpdatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		m.UpdatedAt = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
-	_, err := db.ExecExpr(github_com_go_courier_sqlx_v2.InsertToDB(db, m, nil))
+	_, err := db.ExecExpr(github_com_kunlun_qilian_sqlx_v3.InsertToDB(db, m, nil))
 	return err
 
 }
 
-func (m *User) CreateOnDuplicateWithUpdateFields(db github_com_go_courier_sqlx_v2.DBExecutor, updateFields []string) error {
+func (m *User) CreateOnDuplicateWithUpdateFields(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, updateFields []string) error {
 
 	if len(updateFields) == 0 {
 		panic(fmt.Errorf("must have update fields"))
 	}
 
 	if m.CreatedAt.IsZero() {
-		m.CreatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		m.CreatedAt = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	if m.UpdatedAt.IsZero() {
-		m.UpdatedAt = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		m.UpdatedAt = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, updateFields...)
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValuesFromStructByNonZero(m, updateFields...)
 
 	delete(fieldValues, "ID")
 
@@ -255,60 +255,55 @@ func (m *User) CreateOnDuplicateWithUpdateFields(db github_com_go_courier_sqlx_v
 		}
 	}
 
-	additions := github_com_go_courier_sqlx_v2_builder.Additions{}
+	additions := github_com_kunlun_qilian_sqlx_v3_builder.Additions{}
 
-	switch db.Dialect().DriverName() {
-	case "mysql":
-		additions = append(additions, github_com_go_courier_sqlx_v2_builder.OnDuplicateKeyUpdate(table.AssignmentsByFieldValues(fieldValues)...))
-	case "postgres":
-		indexes := m.UniqueIndexes()
-		fields := make([]string, 0)
-		for _, fs := range indexes {
-			fields = append(fields, fs...)
-		}
-		indexFields, _ := db.T(m).Fields(fields...)
-
-		additions = append(additions,
-			github_com_go_courier_sqlx_v2_builder.OnConflict(indexFields).
-				DoUpdateSet(table.AssignmentsByFieldValues(fieldValues)...))
+	indexes := m.UniqueIndexes()
+	indexFieldNames := make([]string, 0)
+	for _, fs := range indexes {
+		indexFieldNames = append(indexFieldNames, fs...)
 	}
+	indexFields, _ := table.Fields(indexFieldNames...)
+
+	additions = append(additions,
+		github_com_kunlun_qilian_sqlx_v3_builder.Upsert(indexFields).
+			DoUpdate(table.AssignmentsByFieldValues(fieldValues)...))
 
-	additions = append(additions, github_com_go_courier_sqlx_v2_builder.Comment("User.CreateOnDuplicateWithUpdateFields"))
+	additions = append(additions, github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.CreateOnDuplicateWithUpdateFields"))
 
-	expr := github_com_go_courier_sqlx_v2_builder.Insert().Into(table, additions...).Values(cols, vals...)
+	expr := github_com_kunlun_qilian_sqlx_v3_builder.Insert().Into(table, additions...).Values(cols, vals...)
 
 	_, err := db.ExecExpr(expr)
 	return err
 
 }
 
-func (m *User) DeleteByStruct(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) DeleteByStruct(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Delete().
+		github_com_kunlun_qilian_sqlx_v3_builder.Delete().
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(m.ConditionByStruct(db)),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.DeleteByStruct"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(m.ConditionByStruct(db)),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.DeleteByStruct"),
 			),
 	)
 
 	return err
 }
 
-func (m *User) FetchByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) FetchByID(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				)),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.FetchByID"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.FetchByID"),
 			),
 		m,
 	)
@@ -316,22 +311,22 @@ func (m *User) FetchByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 	return err
 }
 
-func (m *User) UpdateByIDWithMap(db github_com_go_courier_sqlx_v2.DBExecutor, fieldValues github_com_go_courier_sqlx_v2_builder.FieldValues) error {
+func (m *User) UpdateByIDWithMap(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, fieldValues github_com_kunlun_qilian_sqlx_v3_builder.FieldValues) error {
 
 	if _, ok := fieldValues["UpdatedAt"]; !ok {
-		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		fieldValues["UpdatedAt"] = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	table := db.T(m)
 
 	result, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+		github_com_kunlun_qilian_sqlx_v3_builder.Update(db.T(m)).
 			Where(
-				github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.UpdateByIDWithMap"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.UpdateByIDWithMap"),
 			).
 			Set(table.AssignmentsByFieldValues(fieldValues)...),
 	)
@@ -349,27 +344,27 @@ func (m *User) UpdateByIDWithMap(db github_com_go_courier_sqlx_v2.DBExecutor, fi
 
 }
 
-func (m *User) UpdateByIDWithStruct(db github_com_go_courier_sqlx_v2.DBExecutor, zeroFields ...string) error {
+func (m *User) UpdateByIDWithStruct(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, zeroFields ...string) error {
 
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
 	return m.UpdateByIDWithMap(db, fieldValues)
 
 }
 
-func (m *User) FetchByIDForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) FetchByIDForUpdate(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				)),
-				github_com_go_courier_sqlx_v2_builder.ForUpdate(),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.FetchByIDForUpdate"),
+				github_com_kunlun_qilian_sqlx_v3_builder.ForUpdate(),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.FetchByIDForUpdate"),
 			),
 		m,
 	)
@@ -377,44 +372,44 @@ func (m *User) FetchByIDForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) e
 	return err
 }
 
-func (m *User) DeleteByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) DeleteByID(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Delete().
+		github_com_kunlun_qilian_sqlx_v3_builder.Delete().
 			From(db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				)),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.DeleteByID"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.DeleteByID"),
 			))
 
 	return err
 }
 
-func (m *User) SoftDeleteByID(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) SoftDeleteByID(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValues{}
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValues{}
 	if _, ok := fieldValues["DeletedAt"]; !ok {
-		fieldValues["DeletedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		fieldValues["DeletedAt"] = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	if _, ok := fieldValues["UpdatedAt"]; !ok {
-		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		fieldValues["UpdatedAt"] = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+		github_com_kunlun_qilian_sqlx_v3_builder.Update(db.T(m)).
 			Where(
-				github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("ID").Eq(m.ID),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.SoftDeleteByID"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.SoftDeleteByID"),
 			).
 			Set(table.AssignmentsByFieldValues(fieldValues)...),
 	)
@@ -423,19 +418,19 @@ func (m *User) SoftDeleteByID(db github_com_go_courier_sqlx_v2.DBExecutor) error
 
 }
 
-func (m *User) FetchByName(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) FetchByName(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("Name").Eq(m.Name),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				)),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.FetchByName"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.FetchByName"),
 			),
 		m,
 	)
@@ -443,22 +438,22 @@ func (m *User) FetchByName(db github_com_go_courier_sqlx_v2.DBExecutor) error {
 	return err
 }
 
-func (m *User) UpdateByNameWithMap(db github_com_go_courier_sqlx_v2.DBExecutor, fieldValues github_com_go_courier_sqlx_v2_builder.FieldValues) error {
+func (m *User) UpdateByNameWithMap(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, fieldValues github_com_kunlun_qilian_sqlx_v3_builder.FieldValues) error {
 
 	if _, ok := fieldValues["UpdatedAt"]; !ok {
-		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		fieldValues["UpdatedAt"] = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	table := db.T(m)
 
 	result, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+		github_com_kunlun_qilian_sqlx_v3_builder.Update(db.T(m)).
 			Where(
-				github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("Name").Eq(m.Name),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.UpdateByNameWithMap"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.UpdateByNameWithMap"),
 			).
 			Set(table.AssignmentsByFieldValues(fieldValues)...),
 	)
@@ -476,27 +471,27 @@ func (m *User) UpdateByNameWithMap(db github_com_go_courier_sqlx_v2.DBExecutor,
 
 }
 
-func (m *User) UpdateByNameWithStruct(db github_com_go_courier_sqlx_v2.DBExecutor, zeroFields ...string) error {
+func (m *User) UpdateByNameWithStruct(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, zeroFields ...string) error {
 
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValuesFromStructByNonZero(m, zeroFields...)
 	return m.UpdateByNameWithMap(db, fieldValues)
 
 }
 
-func (m *User) FetchByNameForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) FetchByNameForUpdate(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(
 				db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("Name").Eq(m.Name),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				)),
-				github_com_go_courier_sqlx_v2_builder.ForUpdate(),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.FetchByNameForUpdate"),
+				github_com_kunlun_qilian_sqlx_v3_builder.ForUpdate(),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.FetchByNameForUpdate"),
 			),
 		m,
 	)
@@ -504,44 +499,44 @@ func (m *User) FetchByNameForUpdate(db github_com_go_courier_sqlx_v2.DBExecutor)
 	return err
 }
 
-func (m *User) DeleteByName(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) DeleteByName(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
 	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Delete().
+		github_com_kunlun_qilian_sqlx_v3_builder.Delete().
 			From(db.T(m),
-				github_com_go_courier_sqlx_v2_builder.Where(github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.Where(github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("Name").Eq(m.Name),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				)),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.DeleteByName"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.DeleteByName"),
 			))
 
 	return err
 }
 
-func (m *User) SoftDeleteByName(db github_com_go_courier_sqlx_v2.DBExecutor) error {
+func (m *User) SoftDeleteByName(db github_com_kunlun_qilian_sqlx_v3.DBExecutor) error {
 
 	table := db.T(m)
 
-	fieldValues := github_com_go_courier_sqlx_v2_builder.FieldValues{}
+	fieldValues := github_com_kunlun_qilian_sqlx_v3_builder.FieldValues{}
 	if _, ok := fieldValues["DeletedAt"]; !ok {
-		fieldValues["DeletedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		fieldValues["DeletedAt"] = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	if _, ok := fieldValues["UpdatedAt"]; !ok {
-		fieldValues["UpdatedAt"] = github_com_go_courier_sqlx_v2_datatypes.Timestamp(time.Now())
+		fieldValues["UpdatedAt"] = github_com_kunlun_qilian_sqlx_v3_datatypes.Timestamp(time.Now())
 	}
 
 	_, err := db.ExecExpr(
-		github_com_go_courier_sqlx_v2_builder.Update(db.T(m)).
+		github_com_kunlun_qilian_sqlx_v3_builder.Update(db.T(m)).
 			Where(
-				github_com_go_courier_sqlx_v2_builder.And(
+				github_com_kunlun_qilian_sqlx_v3_builder.And(
 					table.F("Name").Eq(m.Name),
 					table.F("DeletedAt").Eq(m.DeletedAt),
 				),
-				github_com_go_courier_sqlx_v2_builder.Comment("User.SoftDeleteByName"),
+				github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.SoftDeleteByName"),
 			).
 			Set(table.AssignmentsByFieldValues(fieldValues)...),
 	)
@@ -550,18 +545,18 @@ func (m *User) SoftDeleteByName(db github_com_go_courier_sqlx_v2.DBExecutor) err
 
 }
 
-func (m *User) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) ([]User, error) {
+func (m *User) List(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, condition github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, additions ...github_com_kunlun_qilian_sqlx_v3_builder.Addition) ([]User, error) {
 
 	list := make([]User, 0)
 
 	table := db.T(m)
 	_ = table
 
-	condition = github_com_go_courier_sqlx_v2_builder.And(condition, table.F("DeletedAt").Eq(0))
+	condition = github_com_kunlun_qilian_sqlx_v3_builder.And(condition, table.F("DeletedAt").Eq(0))
 
-	finalAdditions := []github_com_go_courier_sqlx_v2_builder.Addition{
-		github_com_go_courier_sqlx_v2_builder.Where(condition),
-		github_com_go_courier_sqlx_v2_builder.Comment("User.List"),
+	finalAdditions := []github_com_kunlun_qilian_sqlx_v3_builder.Addition{
+		github_com_kunlun_qilian_sqlx_v3_builder.Where(condition),
+		github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.List"),
 	}
 
 	if len(additions) > 0 {
@@ -569,7 +564,7 @@ func (m *User) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition githu
 	}
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(nil).
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
 			From(db.T(m), finalAdditions...),
 		&list,
 	)
@@ -578,18 +573,18 @@ func (m *User) List(db github_com_go_courier_sqlx_v2.DBExecutor, condition githu
 
 }
 
-func (m *User) Count(db github_com_go_courier_sqlx_v2.DBExecutor, condition github_com_go_courier_sqlx_v2_builder.SqlCondition, additions ...github_com_go_courier_sqlx_v2_builder.Addition) (int, error) {
+func (m *User) Count(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, condition github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, additions ...github_com_kunlun_qilian_sqlx_v3_builder.Addition) (int, error) {
 
 	count := -1
 
 	table := db.T(m)
 	_ = table
 
-	condition = github_com_go_courier_sqlx_v2_builder.And(condition, table.F("DeletedAt").Eq(0))
+	condition = github_com_kunlun_qilian_sqlx_v3_builder.And(condition, table.F("DeletedAt").Eq(0))
 
-	finalAdditions := []github_com_go_courier_sqlx_v2_builder.Addition{
-		github_com_go_courier_sqlx_v2_builder.Where(condition),
-		github_com_go_courier_sqlx_v2_builder.Comment("User.Count"),
+	finalAdditions := []github_com_kunlun_qilian_sqlx_v3_builder.Addition{
+		github_com_kunlun_qilian_sqlx_v3_builder.Where(condition),
+		github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.Count"),
 	}
 
 	if len(additions) > 0 {
@@ -597,8 +592,8 @@ func (m *User) Count(db github_com_go_courier_sqlx_v2.DBExecutor, condition gith
 	}
 
 	err := db.QueryExprAndScan(
-		github_com_go_courier_sqlx_v2_builder.Select(
-			github_com_go_courier_sqlx_v2_builder.Count(),
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(
+			github_com_kunlun_qilian_sqlx_v3_builder.Count(),
 		).
 			From(db.T(m), finalAdditions...),
 		&count,
@@ -608,7 +603,37 @@ func (m *User) Count(db github_com_go_courier_sqlx_v2.DBExecutor, condition gith
 
 }
 
-func (m *User) BatchFetchByIDList(db github_com_go_courier_sqlx_v2.DBExecutor, values []uint64) ([]User, error) {
+func (m *User) Page(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, condition github_com_kunlun_qilian_sqlx_v3_builder.SqlCondition, page github_com_kunlun_qilian_sqlx_v3.Page, additions ...github_com_kunlun_qilian_sqlx_v3_builder.Addition) ([]User, *github_com_kunlun_qilian_sqlx_v3.PageInfo, error) {
+
+	list := make([]User, 0)
+
+	table := db.T(m)
+	_ = table
+
+	condition = github_com_kunlun_qilian_sqlx_v3_builder.And(condition, table.F("DeletedAt").Eq(0))
+
+	finalAdditions := []github_com_kunlun_qilian_sqlx_v3_builder.Addition{
+		github_com_kunlun_qilian_sqlx_v3_builder.Where(condition),
+		github_com_kunlun_qilian_sqlx_v3_builder.Comment("User.Page"),
+	}
+
+	if len(additions) > 0 {
+		finalAdditions = append(finalAdditions, additions...)
+	}
+
+	info, err := github_com_kunlun_qilian_sqlx_v3.Paginate(
+		db,
+		github_com_kunlun_qilian_sqlx_v3_builder.Select(nil).
+			From(db.T(m), finalAdditions...),
+		page,
+		&list,
+	)
+
+	return list, info, err
+
+}
+
+func (m *User) BatchFetchByIDList(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, values []uint64) ([]User, error) {
 
 	if len(values) == 0 {
 		return nil, nil
@@ -622,7 +647,7 @@ func (m *User) BatchFetchByIDList(db github_com_go_courier_sqlx_v2.DBExecutor, v
 
 }
 
-func (m *User) BatchFetchByNameList(db github_com_go_courier_sqlx_v2.DBExecutor, values []string) ([]User, error) {
+func (m *User) BatchFetchByNameList(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, values []string) ([]User, error) {
 
 	if len(values) == 0 {
 		return nil, nil
@@ -636,7 +661,7 @@ func (m *User) BatchFetchByNameList(db github_com_go_courier_sqlx_v2.DBExecutor,
 
 }
 
-func (m *User) BatchFetchByNicknameList(db github_com_go_courier_sqlx_v2.DBExecutor, values []string) ([]User, error) {
+func (m *User) BatchFetchByNicknameList(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, values []string) ([]User, error) {
 
 	if len(values) == 0 {
 		return nil, nil
@@ -650,7 +675,7 @@ func (m *User) BatchFetchByNicknameList(db github_com_go_courier_sqlx_v2.DBExecu
 
 }
 
-func (m *User) BatchFetchByUsernameList(db github_com_go_courier_sqlx_v2.DBExecutor, values []string) ([]User, error) {
+func (m *User) BatchFetchByUsernameList(db github_com_kunlun_qilian_sqlx_v3.DBExecutor, values []string) ([]User, error) {
 
 	if len(values) == 0 {
 		return nil, nil
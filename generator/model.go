@@ -109,6 +109,7 @@ func (m *Model) WriteTo(file *codegen.File) {
 		m.WriteCRUD(file)
 		m.WriteList(file)
 		m.WriteCount(file)
+		m.WritePage(file)
 		m.WriteBatchList(file)
 	}
 }
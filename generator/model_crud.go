@@ -146,21 +146,16 @@ for field := range fieldValues {
 
 additions := `+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Additions")+`{}
 
-switch db.Dialect().DriverName() {
-case "mysql":	
-	additions = append(additions, `+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "OnDuplicateKeyUpdate")+`(table.AssignmentsByFieldValues(fieldValues)...))
-case "postgres":
-	indexes := m.UniqueIndexes()
-	fields := make([]string, 0)
-	for _, fs := range indexes {
-		fields = append(fields, fs...)
-	}
-	indexFields, _ := db.T(m).Fields(fields...)
-
-	additions = append(additions,
-			`+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "OnConflict")+`(indexFields).
-				DoUpdateSet(table.AssignmentsByFieldValues(fieldValues)...))
+indexes := m.UniqueIndexes()
+indexFieldNames := make([]string, 0)
+for _, fs := range indexes {
+	indexFieldNames = append(indexFieldNames, fs...)
 }
+indexFields, _ := table.Fields(indexFieldNames...)
+
+additions = append(additions,
+	`+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Upsert")+`(indexFields).
+		DoUpdate(table.AssignmentsByFieldValues(fieldValues)...))
 
 additions = append(additions, `+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Comment")+`("User.CreateOnDuplicateWithUpdateFields"))
 
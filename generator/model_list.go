@@ -120,6 +120,66 @@ return list, err
 	)
 }
 
+func (m *Model) WritePage(file *codegen.File) {
+	file.WriteBlock(
+		codegen.Func(
+			codegen.Var(codegen.Type(file.Use("github.com/kunlun-qilian/sqlx/v3", "DBExecutor")), "db"),
+			codegen.Var(codegen.Type(file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "SqlCondition")), "condition"),
+			codegen.Var(codegen.Type(file.Use("github.com/kunlun-qilian/sqlx/v3", "Page")), "page"),
+			codegen.Var(codegen.Ellipsis(codegen.Type(file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Addition"))), "additions"),
+		).
+			Named("Page").
+			MethodOf(codegen.Var(m.PtrType(), "m")).
+			Return(
+				codegen.Var(codegen.Slice(codegen.Type(m.StructName))),
+				codegen.Var(codegen.Star(codegen.Type(file.Use("github.com/kunlun-qilian/sqlx/v3", "PageInfo")))),
+				codegen.Var(codegen.Error),
+			).
+			Do(
+				codegen.Expr(`
+list := make([]`+m.StructName+`, 0)
+
+table := db.T(m)
+_ = table
+`),
+
+				func() codegen.Snippet {
+					if m.HasDeletedAt {
+						return codegen.Expr(
+							`condition = ?(condition, table.F("`+m.FieldKeyDeletedAt+`").Eq(0))`,
+							codegen.Id(file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "And")),
+						)
+					}
+					return nil
+				}(),
+
+				codegen.Expr(`
+
+finalAdditions := []`+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Addition")+`{
+`+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Where")+`(condition),
+`+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Comment")+`(?),
+}
+
+if len(additions) > 0 {
+	finalAdditions = append(finalAdditions, additions...)
+}
+
+info, err := `+file.Use("github.com/kunlun-qilian/sqlx/v3", "Paginate")+`(
+db,
+`+file.Use("github.com/kunlun-qilian/sqlx/v3/builder", "Select")+`(nil).
+From(db.T(m), finalAdditions...),
+page,
+&list,
+)
+
+return list, info, err
+`,
+					file.Val(m.StructName+".Page"),
+				),
+			),
+	)
+}
+
 func (m *Model) WriteBatchList(file *codegen.File) {
 	indexedFields := m.IndexFieldNames()
 
@@ -14,6 +14,7 @@ require (
 	github.com/google/uuid v1.4.0
 	github.com/kunlun-qilian/utils v0.0.2
 	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.17
 	github.com/onsi/gomega v1.30.0
 	github.com/pkg/errors v0.9.1
 )
@@ -0,0 +1,33 @@
+package sqlx_test
+
+import (
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestInsertToDBWithUpsert(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_upsert", &Member{})
+
+	table := db.T(&Member{})
+
+	for _, age := range []int32{18, 20} {
+		_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "a", Age: age}, nil,
+			builder.Upsert(table.Key("i_name")).UpdateExcluded(table.F("Age")),
+		))
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	}
+
+	_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "a", Age: 30}, nil,
+		builder.Upsert(table.Key("i_name")).DoNothing(),
+	))
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	list := make([]Member, 0)
+	err = db.QueryExprAndScan(builder.Select(nil).From(table), &list)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(1))
+	gomega.NewWithT(t).Expect(list[0].Age).To(gomega.Equal(int32(20)))
+}
@@ -0,0 +1,93 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+)
+
+type ExecHandler func(ctx context.Context, e *builder.Ex) (sql.Result, error)
+type QueryHandler func(ctx context.Context, e *builder.Ex) (*sql.Rows, error)
+
+// ExprInfo info of db which the expr executing on
+type ExprInfo struct {
+	Dialect builder.Dialect
+	IsTx    bool
+}
+
+// Interceptor wraps DB.ExecExpr and DB.QueryExpr,
+// could modify the resolved expr, short-circuit by not calling next, or record the result.
+type Interceptor interface {
+	InterceptExec(ctx context.Context, info ExprInfo, e *builder.Ex, next ExecHandler) (sql.Result, error)
+	InterceptQuery(ctx context.Context, info ExprInfo, e *builder.Ex, next QueryHandler) (*sql.Rows, error)
+}
+
+// InterceptorFuncs Interceptor by funcs, nil func will pass through
+type InterceptorFuncs struct {
+	Exec  func(ctx context.Context, info ExprInfo, e *builder.Ex, next ExecHandler) (sql.Result, error)
+	Query func(ctx context.Context, info ExprInfo, e *builder.Ex, next QueryHandler) (*sql.Rows, error)
+}
+
+func (i InterceptorFuncs) InterceptExec(ctx context.Context, info ExprInfo, e *builder.Ex, next ExecHandler) (sql.Result, error) {
+	if i.Exec == nil {
+		return next(ctx, e)
+	}
+	return i.Exec(ctx, info, e, next)
+}
+
+func (i InterceptorFuncs) InterceptQuery(ctx context.Context, info ExprInfo, e *builder.Ex, next QueryHandler) (*sql.Rows, error) {
+	if i.Query == nil {
+		return next(ctx, e)
+	}
+	return i.Query(ctx, info, e, next)
+}
+
+// WithInterceptors return db with interceptors appended, the first one is the outermost
+func (d *DB) WithInterceptors(interceptors ...Interceptor) *DB {
+	dd := new(DB)
+	*dd = *d
+	dd.interceptors = append(append([]Interceptor{}, d.interceptors...), interceptors...)
+	return dd
+}
+
+func (d *DB) exprInfo() ExprInfo {
+	return ExprInfo{
+		Dialect: d.dialect,
+		IsTx:    d.IsTx(),
+	}
+}
+
+func (d *DB) execHandler() ExecHandler {
+	next := ExecHandler(func(ctx context.Context, e *builder.Ex) (sql.Result, error) {
+		return d.ExecContext(ctx, e.Query(), e.Args()...)
+	})
+
+	info := d.exprInfo()
+
+	for i := len(d.interceptors) - 1; i >= 0; i-- {
+		interceptor, h := d.interceptors[i], next
+		next = func(ctx context.Context, e *builder.Ex) (sql.Result, error) {
+			return interceptor.InterceptExec(ctx, info, e, h)
+		}
+	}
+
+	return next
+}
+
+func (d *DB) queryHandler() QueryHandler {
+	next := QueryHandler(func(ctx context.Context, e *builder.Ex) (*sql.Rows, error) {
+		return d.QueryContext(ctx, e.Query(), e.Args()...)
+	})
+
+	info := d.exprInfo()
+
+	for i := len(d.interceptors) - 1; i >= 0; i-- {
+		interceptor, h := d.interceptors[i], next
+		next = func(ctx context.Context, e *builder.Ex) (*sql.Rows, error) {
+			return interceptor.InterceptQuery(ctx, info, e, h)
+		}
+	}
+
+	return next
+}
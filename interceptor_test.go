@@ -0,0 +1,70 @@
+package sqlx_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/onsi/gomega"
+)
+
+func TestInterceptors(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_interceptors", &Member{})
+
+	records := make([]string, 0)
+
+	recorder := sqlx.InterceptorFuncs{
+		Exec: func(ctx context.Context, info sqlx.ExprInfo, e *builder.Ex, next sqlx.ExecHandler) (sql.Result, error) {
+			records = append(records, fmt.Sprintf("%s %v exec %s", info.Dialect.DriverName(), info.IsTx, e.Query()))
+			return next(ctx, e)
+		},
+		Query: func(ctx context.Context, info sqlx.ExprInfo, e *builder.Ex, next sqlx.QueryHandler) (*sql.Rows, error) {
+			records = append(records, fmt.Sprintf("%s %v query %s", info.Dialect.DriverName(), info.IsTx, e.Query()))
+			return next(ctx, e)
+		},
+	}
+
+	errReadOnly := fmt.Errorf("read only")
+
+	guard := sqlx.InterceptorFuncs{
+		Exec: func(ctx context.Context, info sqlx.ExprInfo, e *builder.Ex, next sqlx.ExecHandler) (sql.Result, error) {
+			if !info.IsTx {
+				return nil, errReadOnly
+			}
+			return next(ctx, e)
+		},
+	}
+
+	tagging := sqlx.InterceptorFuncs{
+		Query: func(ctx context.Context, info sqlx.ExprInfo, e *builder.Ex, next sqlx.QueryHandler) (*sql.Rows, error) {
+			return next(ctx, builder.Expr(e.Query()+" /* tagged */", e.Args()...))
+		},
+	}
+
+	dbWithInterceptors := db.WithInterceptors(recorder, guard, tagging)
+
+	_, err := dbWithInterceptors.ExecExpr(sqlx.InsertToDB(dbWithInterceptors, &Member{Name: "a"}, nil))
+	gomega.NewWithT(t).Expect(err).To(gomega.Equal(errReadOnly))
+
+	err = sqlx.NewTasks(dbWithInterceptors).
+		With(func(db sqlx.DBExecutor) error {
+			_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "a"}, nil))
+			return err
+		}).
+		Do()
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	list := make([]Member, 0)
+	err = dbWithInterceptors.QueryExprAndScan(builder.Select(nil).From(db.T(&Member{})), &list)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(1))
+
+	gomega.NewWithT(t).Expect(records).To(gomega.Equal([]string{
+		"sqlite false exec INSERT INTO t_member (f_name) VALUES (?)",
+		"sqlite true exec INSERT INTO t_member (f_name) VALUES (?)",
+		"sqlite false query SELECT * FROM t_member",
+	}))
+}
@@ -0,0 +1,207 @@
+package sqlx
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	reflectx "github.com/go-courier/x/reflect"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/pkg/errors"
+)
+
+// Page of Paginate
+type Page struct {
+	// Size max count of items of the page, all items when not positive
+	Size int64
+	// Offset of items for offset pagination
+	Offset int64
+	// Keyset use keyset pagination by columns of ORDER BY, Offset will be ignored
+	Keyset bool
+	// Cursor token of PageInfo.NextCursor from previous page for keyset pagination, empty for the first page
+	Cursor string
+	// WithoutTotal skip counting total
+	WithoutTotal bool
+}
+
+// PageInfo result of Paginate
+type PageInfo struct {
+	// Total count of all items, -1 when counting skipped
+	Total int64
+	// NextCursor token of next page for keyset pagination, empty when no more items
+	NextCursor string
+}
+
+// Paginate query items of page into list, like *[]User, and count total of all items by count query.
+// the count query and the page query run in one read-only REPEATABLE READ transaction for a consistent snapshot,
+// or in the transaction of db when already in transaction, *RWDB begins the read-only transaction on a replica.
+// for keyset pagination, statement should be ordered by columns of fields of the item,
+// and last ORDER BY column should be unique, like primary key.
+func Paginate(db DBExecutor, stmt *builder.StmtSelect, page Page, list interface{}) (*PageInfo, error) {
+	maybeTx, ok := db.(MaybeTxExecutor)
+	if page.WithoutTotal || !ok || maybeTx.IsTx() {
+		return paginate(db, stmt, page, list)
+	}
+
+	tx, err := maybeTx.BeginTx(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := paginate(tx, stmt, page, list)
+	if err != nil {
+		_ = tx.(MaybeTxExecutor).Rollback()
+		return nil, err
+	}
+
+	if err := tx.(MaybeTxExecutor).Commit(); err != nil {
+		return nil, err
+	}
+
+	return info, nil
+}
+
+func paginate(db DBExecutor, stmt *builder.StmtSelect, page Page, list interface{}) (*PageInfo, error) {
+	info := &PageInfo{Total: -1}
+
+	if !page.WithoutTotal {
+		if err := db.QueryExprAndScan(builder.CountOf(stmt), &info.Total); err != nil {
+			return nil, err
+		}
+	}
+
+	pageStmt := stmt.WithoutAdditions(builder.AdditionLimit)
+
+	var keyset *pageKeyset
+
+	if page.Keyset || page.Cursor != "" {
+		k, err := newPageKeyset(stmt.Orders(), list)
+		if err != nil {
+			return nil, err
+		}
+		keyset = k
+
+		if page.Cursor != "" {
+			values, err := keyset.decode(page.Cursor)
+			if err != nil {
+				return nil, err
+			}
+			pageStmt = pageStmt.WithAdditions(builder.Where(builder.KeysetCond(keyset.orders, values)))
+		}
+
+		if page.Size > 0 {
+			pageStmt = pageStmt.WithAdditions(builder.Limit(page.Size))
+		}
+	} else if page.Size > 0 {
+		pageStmt = pageStmt.WithAdditions(builder.Limit(page.Size).Offset(page.Offset))
+	}
+
+	if err := db.QueryExprAndScan(pageStmt, list); err != nil {
+		return nil, err
+	}
+
+	if keyset != nil && page.Size > 0 {
+		cursor, err := keyset.next(list, page.Size)
+		if err != nil {
+			return nil, err
+		}
+		info.NextCursor = cursor
+	}
+
+	return info, nil
+}
+
+type pageKeyset struct {
+	orders     []*builder.Order
+	fieldNames []string
+	fieldTypes []reflect.Type
+}
+
+func newPageKeyset(orders []*builder.Order, list interface{}) (*pageKeyset, error) {
+	if len(orders) == 0 {
+		return nil, fmt.Errorf("keyset pagination should order by columns")
+	}
+
+	listType := reflectx.Deref(reflect.TypeOf(list))
+	if listType.Kind() != reflect.Slice || reflectx.Deref(listType.Elem()).Kind() != reflect.Struct {
+		return nil, fmt.Errorf("keyset pagination should scan into slice of struct, but got %T", list)
+	}
+
+	k := &pageKeyset{
+		orders:     orders,
+		fieldNames: make([]string, len(orders)),
+		fieldTypes: make([]reflect.Type, len(orders)),
+	}
+
+	for i := range orders {
+		col, ok := orders[i].Target().(*builder.Column)
+		if !ok || col.FieldName == "" {
+			return nil, fmt.Errorf("keyset pagination should order by columns of fields, but got %T", orders[i].Target())
+		}
+		k.fieldNames[i] = col.FieldName
+	}
+
+	fieldValues := builder.FieldValuesFromStructBy(reflect.New(reflectx.Deref(listType.Elem())).Interface(), k.fieldNames)
+
+	for i, fieldName := range k.fieldNames {
+		v, ok := fieldValues[fieldName]
+		if !ok {
+			return nil, fmt.Errorf("missing field %s of %s for keyset pagination", fieldName, listType.Elem())
+		}
+		k.fieldTypes[i] = reflect.TypeOf(v)
+	}
+
+	return k, nil
+}
+
+// next returns cursor by values of the last item, empty when items less than size
+func (k *pageKeyset) next(list interface{}, size int64) (string, error) {
+	rv := reflectx.Indirect(reflect.ValueOf(list))
+	if n := rv.Len(); n == 0 || int64(n) < size {
+		return "", nil
+	}
+
+	fieldValues := builder.FieldValuesFromStructBy(rv.Index(rv.Len()-1).Interface(), k.fieldNames)
+
+	values := make([]interface{}, len(k.fieldNames))
+	for i, fieldName := range k.fieldNames {
+		values[i] = fieldValues[fieldName]
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func (k *pageKeyset) decode(cursor string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+
+	rawValues := make([]json.RawMessage, 0, len(k.fieldTypes))
+	if err := json.Unmarshal(data, &rawValues); err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+
+	if len(rawValues) != len(k.fieldTypes) {
+		return nil, fmt.Errorf("invalid cursor: count of values %d not match orders %d", len(rawValues), len(k.fieldTypes))
+	}
+
+	values := make([]interface{}, len(rawValues))
+
+	for i := range rawValues {
+		rv := reflect.New(k.fieldTypes[i])
+		if err := json.Unmarshal(rawValues[i], rv.Interface()); err != nil {
+			return nil, errors.Wrap(err, "invalid cursor")
+		}
+		values[i] = rv.Elem().Interface()
+	}
+
+	return values, nil
+}
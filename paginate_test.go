@@ -0,0 +1,131 @@
+package sqlx_test
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/migration"
+	. "github.com/onsi/gomega"
+)
+
+func TestPaginate(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_paginate", &Member{})
+
+	table := db.T(&Member{})
+
+	members := make([]Member, 0)
+	for i := 0; i < 25; i++ {
+		members = append(members, Member{Name: "a" + strconv.Itoa(i), Age: int32(i % 3)})
+	}
+
+	_, err := sqlx.BulkInsert(db, table, sqlx.BulkRowsOf(members))
+	NewWithT(t).Expect(err).To(BeNil())
+
+	t.Run("offset", func(t *testing.T) {
+		stmt := builder.Select(nil).From(
+			table,
+			builder.Where(table.F("Age").Eq(1)),
+			builder.OrderBy(builder.AscOrder(table.F("ID"))),
+		)
+
+		list := make([]Member, 0)
+		info, err := sqlx.Paginate(db, stmt, sqlx.Page{Size: 3, Offset: 6}, &list)
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(info.Total).To(Equal(int64(8)))
+		NewWithT(t).Expect(list).To(HaveLen(2))
+		NewWithT(t).Expect(list[0].Name).To(Equal("a19"))
+	})
+
+	t.Run("count and page in one transaction", func(t *testing.T) {
+		inTx := make([]bool, 0)
+
+		recorder := sqlx.InterceptorFuncs{
+			Query: func(ctx context.Context, info sqlx.ExprInfo, e *builder.Ex, next sqlx.QueryHandler) (*sql.Rows, error) {
+				inTx = append(inTx, info.IsTx)
+				return next(ctx, e)
+			},
+		}
+
+		stmt := builder.Select(nil).From(table, builder.OrderBy(builder.AscOrder(table.F("ID"))))
+
+		_, err := sqlx.Paginate(db.WithInterceptors(recorder), stmt, sqlx.Page{Size: 3}, &[]Member{})
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(inTx).To(Equal([]bool{true, true}))
+
+		inTx = inTx[0:0]
+
+		info, err := sqlx.Paginate(db.WithInterceptors(recorder), stmt, sqlx.Page{Size: 3, WithoutTotal: true}, &[]Member{})
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(info.Total).To(Equal(int64(-1)))
+		NewWithT(t).Expect(inTx).To(Equal([]bool{false}))
+	})
+
+	t.Run("read-only transaction on replica of RWDB", func(t *testing.T) {
+		dbTest := sqlx.NewDatabase("test_for_paginate_rw")
+		dbTest.Register(&Member{})
+
+		rwdb := dbTest.OpenRWDB(newSQLiteConnector(t), newSQLiteConnector(t))
+
+		for _, d := range append([]*sqlx.DB{rwdb.Primary()}, rwdb.Replicas()...) {
+			closeAfterTest(t, d)
+			NewWithT(t).Expect(migration.Migrate(d, nil)).To(BeNil())
+		}
+
+		insertMembers(t, rwdb, "a")
+		// only in replica
+		insertMembers(t, rwdb.Replicas()[0], "b", "c")
+
+		stmt := builder.Select(nil).From(rwdb.T(&Member{}), builder.OrderBy(builder.AscOrder(rwdb.T(&Member{}).F("ID"))))
+
+		list := make([]Member, 0)
+		info, err := sqlx.Paginate(rwdb, stmt, sqlx.Page{Size: 1}, &list)
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(info.Total).To(Equal(int64(2)))
+		NewWithT(t).Expect(list[0].Name).To(Equal("b"))
+
+		info, err = sqlx.Paginate(rwdb.WithContext(sqlx.ContextWithPrimaryOnly(rwdb.Context())), stmt, sqlx.Page{Size: 1}, &list)
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(info.Total).To(Equal(int64(1)))
+	})
+
+	t.Run("keyset", func(t *testing.T) {
+		stmt := builder.Select(nil).From(
+			table,
+			builder.OrderBy(builder.DescOrder(table.F("Age")), builder.AscOrder(table.F("ID"))),
+		)
+
+		page := sqlx.Page{Size: 10, Keyset: true}
+		names := make([]string, 0)
+
+		for {
+			list := make([]Member, 0)
+			info, err := sqlx.Paginate(db, stmt, page, &list)
+			NewWithT(t).Expect(err).To(BeNil())
+			NewWithT(t).Expect(info.Total).To(Equal(int64(25)))
+
+			for _, m := range list {
+				names = append(names, m.Name)
+			}
+
+			if info.NextCursor == "" {
+				break
+			}
+			page.Cursor = info.NextCursor
+		}
+
+		NewWithT(t).Expect(names).To(HaveLen(25))
+		NewWithT(t).Expect(names[0:3]).To(Equal([]string{"a2", "a5", "a8"}))
+		NewWithT(t).Expect(names[24]).To(Equal("a24"))
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		stmt := builder.Select(nil).From(table, builder.OrderBy(builder.AscOrder(table.F("ID"))))
+
+		_, err := sqlx.Paginate(db, stmt, sqlx.Page{Size: 10, Cursor: "x"}, &[]Member{})
+		NewWithT(t).Expect(err).NotTo(BeNil())
+	})
+}
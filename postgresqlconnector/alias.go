@@ -3,3 +3,7 @@ package postgresqlconnector
 import "github.com/kunlun-qilian/sqlx/v3/connectors/postgresql"
 
 type PostgreSQLConnector = postgresql.PostgreSQLConnector
+
+type Cursor = postgresql.Cursor
+
+var DeclareCursor = postgresql.DeclareCursor
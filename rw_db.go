@@ -0,0 +1,202 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync/atomic"
+
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/scanner"
+)
+
+type contextKeyPrimaryOnly struct{}
+
+// ContextWithPrimaryOnly force queries to the primary, for read-your-writes after writing
+func ContextWithPrimaryOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyPrimaryOnly{}, true)
+}
+
+func IsPrimaryOnly(ctx context.Context) bool {
+	if v, ok := ctx.Value(contextKeyPrimaryOnly{}).(bool); ok {
+		return v
+	}
+	return false
+}
+
+// ReplicaSelector pick one replica for reading
+type ReplicaSelector interface {
+	Select(replicas []*DB) *DB
+}
+
+// RoundRobin select replicas in turn
+func RoundRobin() ReplicaSelector {
+	return &roundRobin{}
+}
+
+type roundRobin struct {
+	n uint64
+}
+
+func (r *roundRobin) Select(replicas []*DB) *DB {
+	n := atomic.AddUint64(&r.n, 1)
+	return replicas[(n-1)%uint64(len(replicas))]
+}
+
+// LeastConnections select the replica with the least in-use connections
+func LeastConnections() ReplicaSelector {
+	return leastConnections{}
+}
+
+type leastConnections struct{}
+
+func (leastConnections) Select(replicas []*DB) *DB {
+	var selected *DB
+	minInUse := -1
+
+	for i := range replicas {
+		sqlDB, ok := replicas[i].SqlExecutor.(*sql.DB)
+		if !ok {
+			continue
+		}
+		if inUse := sqlDB.Stats().InUse; minInUse == -1 || inUse < minInUse {
+			selected = replicas[i]
+			minInUse = inUse
+		}
+	}
+
+	if selected == nil {
+		return replicas[0]
+	}
+	return selected
+}
+
+// OpenRWDB open db with primary for writing and replicas for reading
+func (database *Database) OpenRWDB(primary driver.Connector, replicas ...driver.Connector) *RWDB {
+	db := &RWDB{
+		DB:       database.OpenDB(primary),
+		selector: RoundRobin(),
+	}
+	for i := range replicas {
+		db.replicas = append(db.replicas, database.OpenDB(replicas[i]))
+	}
+	return db
+}
+
+var _ interface {
+	DBExecutor
+	MaybeTxExecutor
+} = (*RWDB)(nil)
+
+// RWDB DBExecutor with read/write splitting.
+// Only QueryExpr of select out of transaction and read-only transactions will be sent to replicas,
+// writes, other transactions and select for update always go to the primary.
+type RWDB struct {
+	*DB
+	replicas []*DB
+	selector ReplicaSelector
+}
+
+func (d RWDB) WithReplicaSelector(selector ReplicaSelector) *RWDB {
+	d.selector = selector
+	return &d
+}
+
+func (d RWDB) WithInterceptors(interceptors ...Interceptor) *RWDB {
+	d.DB = d.DB.WithInterceptors(interceptors...)
+	replicas := make([]*DB, len(d.replicas))
+	for i := range d.replicas {
+		replicas[i] = d.replicas[i].WithInterceptors(interceptors...)
+	}
+	d.replicas = replicas
+	return &d
+}
+
+func (d RWDB) WithContext(ctx context.Context) DBExecutor {
+	d.DB = d.DB.WithContext(ctx).(*DB)
+	replicas := make([]*DB, len(d.replicas))
+	for i := range d.replicas {
+		replicas[i] = d.replicas[i].WithContext(ctx).(*DB)
+	}
+	d.replicas = replicas
+	return &d
+}
+
+func (d RWDB) WithSchema(schema string) DBExecutor {
+	d.DB = d.DB.WithSchema(schema).(*DB)
+	replicas := make([]*DB, len(d.replicas))
+	for i := range d.replicas {
+		replicas[i] = d.replicas[i].WithSchema(schema).(*DB)
+	}
+	d.replicas = replicas
+	return &d
+}
+
+// Primary return db of primary
+func (d *RWDB) Primary() *DB {
+	return d.DB
+}
+
+// Replicas return dbs of replicas
+func (d *RWDB) Replicas() []*DB {
+	return d.replicas
+}
+
+func (d *RWDB) QueryExpr(expr builder.SqlExpr) (*sql.Rows, error) {
+	e := builder.ResolveExprContext(d.DB.exprContext(), expr)
+	if builder.IsNilExpr(e) {
+		return nil, nil
+	}
+	if err := e.Err(); err != nil {
+		return nil, err
+	}
+	return d.dbForQuery(expr).QueryExpr(e)
+}
+
+func (d *RWDB) QueryExprAndScan(expr builder.SqlExpr, v interface{}) error {
+	exprs, err := d.DB.resolveExprInChunks(expr)
+	if err != nil {
+		return err
+	}
+	return d.DB.classifyError(scanInChunks(d.Context(), exprs, d.dbForQuery(expr).QueryExpr, v))
+}
+
+func (d *RWDB) QueryExprCursor(expr builder.SqlExpr) (*Cursor, error) {
+	rows, err := d.QueryExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	return scanner.NewCursor(d.Context(), rows), nil
+}
+
+func (d *RWDB) QueryExprEach(expr builder.SqlExpr, fn interface{}) error {
+	if _, err := scanner.ScanIteratorFromFunc(fn); err != nil {
+		return err
+	}
+	rows, err := d.QueryExpr(expr)
+	if err != nil {
+		return err
+	}
+	return d.DB.classifyError(scanner.Each(d.Context(), rows, fn))
+}
+
+// BeginTx begin read-only transaction on selected replica when opt is ReadOnly,
+// others begin on primary.
+func (d *RWDB) BeginTx(opt *sql.TxOptions) (DBExecutor, error) {
+	if opt == nil || !opt.ReadOnly || len(d.replicas) == 0 || d.DB.IsTx() || IsPrimaryOnly(d.Context()) {
+		return d.DB.BeginTx(opt)
+	}
+	return d.selector.Select(d.replicas).BeginTx(opt)
+}
+
+// dbForQuery returns replica only for select statement built by builder.Select without PrimaryOnly or locking additions,
+// others like raw expr will be executed on primary.
+func (d *RWDB) dbForQuery(expr builder.SqlExpr) *DB {
+	if len(d.replicas) == 0 || d.DB.IsTx() || IsPrimaryOnly(d.Context()) {
+		return d.DB
+	}
+	if stmt, ok := expr.(*builder.StmtSelect); !ok || stmt.IsPrimaryOnly() {
+		return d.DB
+	}
+	return d.selector.Select(d.replicas)
+}
@@ -0,0 +1,92 @@
+package sqlx_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/migration"
+	"github.com/onsi/gomega"
+)
+
+func TestRWDB(t *testing.T) {
+	dbTest := sqlx.NewDatabase("test_for_rw")
+	dbTest.Register(&Member{})
+
+	db := dbTest.OpenRWDB(newSQLiteConnector(t), newSQLiteConnector(t), newSQLiteConnector(t))
+
+	// sqlite not support FOR UPDATE, strip it for testing.
+	db.DB = db.DB.WithInterceptors(sqlx.InterceptorFuncs{
+		Query: func(ctx context.Context, info sqlx.ExprInfo, e *builder.Ex, next sqlx.QueryHandler) (*sql.Rows, error) {
+			return next(ctx, builder.Expr(strings.NewReplacer("\nFOR UPDATE", "", " FOR UPDATE", "").Replace(e.Query()), e.Args()...))
+		},
+	})
+
+	for _, d := range append([]*sqlx.DB{db.Primary()}, db.Replicas()...) {
+		closeAfterTest(t, d)
+		err := migration.Migrate(d, nil)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	}
+
+	insertMembers(t, db, "a")
+	// only in replica_0
+	insertMembers(t, db.Replicas()[0], "b")
+
+	queryNamesBy := func(db sqlx.DBExecutor, expr builder.SqlExpr) []string {
+		list := make([]Member, 0)
+		err := db.QueryExprAndScan(expr, &list)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		names := make([]string, len(list))
+		for i := range list {
+			names[i] = list[i].Name
+		}
+		return names
+	}
+
+	t.Run("round robin reading from replicas", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(queryMemberNames(t, db)).To(gomega.Equal([]string{"b"}))
+		gomega.NewWithT(t).Expect(queryMemberNames(t, db)).To(gomega.HaveLen(0))
+		gomega.NewWithT(t).Expect(queryMemberNames(t, db)).To(gomega.Equal([]string{"b"}))
+	})
+
+	t.Run("primary only by context", func(t *testing.T) {
+		gomega.NewWithT(t).Expect(queryMemberNames(t, db.WithContext(sqlx.ContextWithPrimaryOnly(db.Context())))).To(gomega.Equal([]string{"a"}))
+	})
+
+	t.Run("primary only by statement", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			gomega.NewWithT(t).Expect(queryNamesBy(db, builder.Select(nil).From(db.T(&Member{})).PrimaryOnly())).To(gomega.Equal([]string{"a"}))
+		}
+	})
+
+	t.Run("for update", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			gomega.NewWithT(t).Expect(queryMemberNames(t, db, builder.ForUpdate())).To(gomega.Equal([]string{"a"}))
+		}
+	})
+
+	t.Run("raw expr", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			gomega.NewWithT(t).Expect(queryNamesBy(db, builder.Expr("SELECT * FROM t_member FOR UPDATE"))).To(gomega.Equal([]string{"a"}))
+			gomega.NewWithT(t).Expect(queryNamesBy(db, builder.Expr("SELECT * FROM t_member"))).To(gomega.Equal([]string{"a"}))
+		}
+	})
+
+	t.Run("in tx", func(t *testing.T) {
+		err := sqlx.NewTasks(db).
+			With(func(db sqlx.DBExecutor) error {
+				gomega.NewWithT(t).Expect(queryMemberNames(t, db)).To(gomega.Equal([]string{"a"}))
+				return nil
+			}).
+			Do()
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	})
+
+	t.Run("least connections", func(t *testing.T) {
+		names := queryMemberNames(t, db.WithReplicaSelector(sqlx.LeastConnections()))
+		gomega.NewWithT(t).Expect(names).To(gomega.Equal([]string{"b"}))
+	})
+}
@@ -0,0 +1,60 @@
+package sqlx_test
+
+import (
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	. "github.com/onsi/gomega"
+)
+
+type MemberOrg struct {
+	ID       uint64 `db:"f_id,autoincrement"`
+	MemberID uint64 `db:"f_member_id"`
+	Name     string `db:"f_name,size=255,default=''"`
+}
+
+func (MemberOrg) TableName() string {
+	return "t_member_org"
+}
+
+func (MemberOrg) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+type MemberWithOrgs struct {
+	Member
+	Orgs []MemberOrg `json:"orgs"`
+}
+
+func TestScanJoinedRows(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_scan_joined_rows", &Member{}, &MemberOrg{})
+
+	insertMembers(t, db, "a", "b")
+
+	for _, name := range []string{"org1", "org2"} {
+		_, err := db.ExecExpr(sqlx.InsertToDB(db, &MemberOrg{MemberID: 1, Name: name}, nil))
+		NewWithT(t).Expect(err).To(BeNil())
+	}
+
+	tMember := db.T(&Member{})
+	tMemberOrg := db.T(&MemberOrg{})
+
+	list := make([]MemberWithOrgs, 0)
+
+	err := db.QueryExprAndScan(
+		builder.Select(builder.ColumnsByStruct(&MemberWithOrgs{})).
+			From(
+				tMember,
+				builder.LeftJoin(tMemberOrg).On(tMemberOrg.F("MemberID").Eq(tMember.F("ID"))),
+				builder.OrderBy(builder.AscOrder(tMember.F("ID")), builder.AscOrder(tMemberOrg.F("ID"))),
+			),
+		&list,
+	)
+	NewWithT(t).Expect(err).To(BeNil())
+	NewWithT(t).Expect(list).To(HaveLen(2))
+	NewWithT(t).Expect(list[0].Name).To(Equal("a"))
+	NewWithT(t).Expect(list[0].Orgs).To(HaveLen(2))
+	NewWithT(t).Expect(list[0].Orgs[1].Name).To(Equal("org2"))
+	NewWithT(t).Expect(list[1].Orgs).To(HaveLen(0))
+}
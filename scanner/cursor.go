@@ -0,0 +1,92 @@
+package scanner
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// StopIteration could be returned by the func of Each to stop iteration without error
+var StopIteration = errors.New("stop iteration")
+
+// NewCursor create cursor to scan rows one by one
+func NewCursor(ctx context.Context, rows *sql.Rows) *Cursor {
+	return &Cursor{rows: rows, scanner: newRowScanner(ctx, rows)}
+}
+
+type Cursor struct {
+	rows    *sql.Rows
+	scanner *rowScanner
+}
+
+// Next prepares the next row for Scan, returns false when no more rows or error occurred
+func (c *Cursor) Next() bool {
+	if c.rows == nil {
+		return false
+	}
+	return c.rows.Next()
+}
+
+// Scan scan current row to v with same mapping of Scan,
+// v with slice fields of one-to-many relation is not supported, for each row only holds one elem of slices.
+func (c *Cursor) Scan(v interface{}) error {
+	if c.rows == nil {
+		return sql.ErrNoRows
+	}
+	if hasSliceStructFields(reflect.TypeOf(v)) {
+		return errSliceRelation(reflect.TypeOf(v))
+	}
+	return c.scanner.scanTo(v)
+}
+
+// Err returns the error during iteration
+func (c *Cursor) Err() error {
+	if c.rows == nil {
+		return nil
+	}
+	return c.rows.Err()
+}
+
+// Close closes the rows, could be called multiple times
+func (c *Cursor) Close() error {
+	if c.rows == nil {
+		return nil
+	}
+	return c.rows.Close()
+}
+
+// Each scan rows one by one and call fn with each row,
+// fn must be func(row T) error or func(row *T) error, T with slice fields of one-to-many relation is not supported.
+// return StopIteration in fn to stop iteration, and rows will be closed.
+func Each(ctx context.Context, rows *sql.Rows, fn interface{}) error {
+	c := NewCursor(ctx, rows)
+	defer c.Close()
+
+	si, err := ScanIteratorFromFunc(fn)
+	if err != nil {
+		return err
+	}
+
+	for c.Next() {
+		item := si.New()
+
+		if err := c.Scan(item); err != nil {
+			return err
+		}
+
+		if err := si.Next(item); err != nil {
+			if err == StopIteration {
+				return c.Close()
+			}
+			return err
+		}
+	}
+
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+	return c.Close()
+}
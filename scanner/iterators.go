@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"fmt"
 	"reflect"
 
 	reflectx "github.com/go-courier/x/reflect"
@@ -21,10 +22,14 @@ func ScanIteratorFor(v interface{}) (ScanIterator, error) {
 		tpe := reflectx.Deref(reflect.TypeOf(v))
 
 		if tpe.Kind() == reflect.Slice && tpe.Elem().Kind() != reflect.Uint8 {
-			return &SliceScanIterator{
+			si := &SliceScanIterator{
 				elemType: tpe.Elem(),
 				rv:       reflectx.Indirect(reflect.ValueOf(v)),
-			}, nil
+			}
+			if hasSliceStructFields(tpe.Elem()) {
+				si.group = newRelationGroup()
+			}
+			return si, nil
 		}
 
 		return &SingleScanIterator{target: v}, nil
@@ -34,6 +39,8 @@ func ScanIteratorFor(v interface{}) (ScanIterator, error) {
 type SliceScanIterator struct {
 	elemType reflect.Type
 	rv       reflect.Value
+	// group rows by primary key when elem with slice fields for one-to-many relation
+	group *relationGroup
 }
 
 func (s *SliceScanIterator) New() interface{} {
@@ -41,6 +48,10 @@ func (s *SliceScanIterator) New() interface{} {
 }
 
 func (s *SliceScanIterator) Next(v interface{}) error {
+	if s.group != nil {
+		s.group.merge(s.rv, reflect.ValueOf(v).Elem())
+		return nil
+	}
 	s.rv.Set(reflect.Append(s.rv, reflect.ValueOf(v).Elem()))
 	return nil
 }
@@ -62,3 +73,48 @@ func (s *SingleScanIterator) Next(v interface{}) error {
 func (s *SingleScanIterator) MustHasRecord() bool {
 	return s.hasResults
 }
+
+var typeError = reflect.TypeOf((*error)(nil)).Elem()
+
+// ScanIteratorFromFunc create ScanIterator which calls fn with each scanned row,
+// fn must be func(row T) error or func(row *T) error.
+func ScanIteratorFromFunc(fn interface{}) (ScanIterator, error) {
+	ft := reflect.TypeOf(fn)
+
+	if ft == nil || ft.Kind() != reflect.Func || ft.NumIn() != 1 || ft.NumOut() != 1 || ft.Out(0) != typeError {
+		return nil, fmt.Errorf("fn must be func(row T) error, but got %T", fn)
+	}
+
+	argType := ft.In(0)
+
+	if hasSliceStructFields(argType) {
+		return nil, errSliceRelation(argType)
+	}
+
+	return &FuncScanIterator{
+		fn:       reflect.ValueOf(fn),
+		elemType: reflectx.Deref(argType),
+		isPtr:    argType.Kind() == reflect.Ptr,
+	}, nil
+}
+
+type FuncScanIterator struct {
+	fn       reflect.Value
+	elemType reflect.Type
+	isPtr    bool
+}
+
+func (s *FuncScanIterator) New() interface{} {
+	return reflectx.New(s.elemType).Addr().Interface()
+}
+
+func (s *FuncScanIterator) Next(v interface{}) error {
+	arg := reflect.ValueOf(v)
+	if !s.isPtr {
+		arg = arg.Elem()
+	}
+	if ret := s.fn.Call([]reflect.Value{arg})[0]; !ret.IsNil() {
+		return ret.Interface().(error)
+	}
+	return nil
+}
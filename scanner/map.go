@@ -0,0 +1,303 @@
+package scanner
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Row values of columns keyed by column name, for queries without struct, like reports of user-defined queries.
+// values are converted by database type of columns, see ConvertColumnValue
+type Row map[string]interface{}
+
+// Get returns value of column and whether the column exists
+func (r Row) Get(column string) (interface{}, bool) {
+	v, ok := r[column]
+	return v, ok
+}
+
+// IsNull returns true when column is NULL or not exists
+func (r Row) IsNull(column string) bool {
+	return r[column] == nil
+}
+
+func (r Row) String(column string) string {
+	switch x := r[column].(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case []byte:
+		return string(x)
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	case json.RawMessage:
+		return string(x)
+	case json.Number:
+		return x.String()
+	default:
+		return fmt.Sprintf("%v", x)
+	}
+}
+
+func (r Row) Int64(column string) int64 {
+	switch x := r[column].(type) {
+	case int64:
+		return x
+	case float64:
+		return int64(x)
+	case json.Number:
+		if i, err := x.Int64(); err == nil {
+			return i
+		}
+		f, _ := x.Float64()
+		return int64(f)
+	case bool:
+		if x {
+			return 1
+		}
+		return 0
+	case string:
+		i, _ := strconv.ParseInt(x, 10, 64)
+		return i
+	case []byte:
+		i, _ := strconv.ParseInt(string(x), 10, 64)
+		return i
+	}
+	return 0
+}
+
+func (r Row) Float64(column string) float64 {
+	switch x := r[column].(type) {
+	case float64:
+		return x
+	case int64:
+		return float64(x)
+	case json.Number:
+		f, _ := x.Float64()
+		return f
+	case string:
+		f, _ := strconv.ParseFloat(x, 64)
+		return f
+	case []byte:
+		f, _ := strconv.ParseFloat(string(x), 64)
+		return f
+	}
+	return 0
+}
+
+func (r Row) Bool(column string) bool {
+	switch x := r[column].(type) {
+	case bool:
+		return x
+	case int64:
+		return x != 0
+	case string:
+		b, _ := strconv.ParseBool(x)
+		return b
+	case []byte:
+		b, _ := strconv.ParseBool(string(x))
+		return b
+	}
+	return false
+}
+
+func (r Row) Time(column string) time.Time {
+	switch x := r[column].(type) {
+	case time.Time:
+		return x
+	case string:
+		t, _ := parseTime(x)
+		return t
+	case []byte:
+		t, _ := parseTime(string(x))
+		return t
+	}
+	return time.Time{}
+}
+
+func (r Row) Bytes(column string) []byte {
+	switch x := r[column].(type) {
+	case []byte:
+		return x
+	case json.RawMessage:
+		return x
+	case string:
+		return []byte(x)
+	}
+	return nil
+}
+
+var typeInterface = reflect.TypeOf((*interface{})(nil)).Elem()
+
+func isMapTarget(tpe reflect.Type) bool {
+	return tpe.Kind() == reflect.Map && tpe.Key().Kind() == reflect.String && tpe.Elem() == typeInterface
+}
+
+func scanToMap(rows *sql.Rows, columns []string, columnTypes []*sql.ColumnType, rv reflect.Value) error {
+	values := make([]interface{}, len(columnTypes))
+	dest := make([]interface{}, len(columnTypes))
+
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(rv.Type(), len(columnTypes)))
+	}
+
+	for i, ct := range columnTypes {
+		v, err := ConvertColumnValue(ct.DatabaseTypeName(), values[i])
+		if err != nil {
+			return fmt.Errorf("convert value of column %s failed: %s", ct.Name(), err)
+		}
+		if v == nil {
+			rv.SetMapIndex(reflect.ValueOf(columns[i]), reflect.Zero(typeInterface))
+			continue
+		}
+		rv.SetMapIndex(reflect.ValueOf(columns[i]), reflect.ValueOf(v))
+	}
+
+	return nil
+}
+
+// ConvertColumnValue converts raw value from driver by database type name of column,
+// integers to int64, floats to float64, decimals to json.Number to keep precision, json to decoded value,
+// times to time.Time, texts and money to string, and binaries kept as []byte.
+func ConvertColumnValue(databaseTypeName string, v interface{}) (interface{}, error) {
+	var raw string
+
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		raw = string(x)
+	case string:
+		raw = x
+	default:
+		return v, nil
+	}
+
+	switch databaseTypeKind(databaseTypeName) {
+	case "int":
+		return strconv.ParseInt(raw, 10, 64)
+	case "uint":
+		u, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if u > 1<<63-1 {
+			return raw, nil
+		}
+		return int64(u), nil
+	case "float":
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			// NaN, Infinity and others as string
+			return raw, nil
+		}
+		return f, nil
+	case "decimal":
+		if !isNumber(raw) {
+			// NaN and others as string
+			return raw, nil
+		}
+		return json.Number(raw), nil
+	case "bool":
+		switch strings.ToLower(raw) {
+		case "t", "true", "1", "y", "yes", "on":
+			return true, nil
+		case "f", "false", "0", "n", "no", "off":
+			return false, nil
+		}
+		return nil, fmt.Errorf("invalid bool value %q", raw)
+	case "json":
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case "time":
+		t, err := parseTime(raw)
+		if err != nil {
+			// TIME and INTERVAL without date as string
+			return raw, nil
+		}
+		return t, nil
+	case "binary":
+		if b, ok := v.([]byte); ok {
+			return b, nil
+		}
+		return []byte(raw), nil
+	}
+
+	return raw, nil
+}
+
+func databaseTypeKind(databaseTypeName string) string {
+	name := strings.ToUpper(databaseTypeName)
+
+	unsigned := strings.HasPrefix(name, "UNSIGNED ")
+	name = strings.TrimPrefix(name, "UNSIGNED ")
+
+	// SQLite like VARCHAR(255)
+	if i := strings.Index(name, "("); i > 0 {
+		name = strings.TrimSpace(name[0:i])
+	}
+
+	switch name {
+	case "INT", "INT2", "INT4", "INT8", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT",
+		"SERIAL", "SERIAL2", "SERIAL4", "SERIAL8", "SMALLSERIAL", "BIGSERIAL", "YEAR":
+		if unsigned {
+			return "uint"
+		}
+		return "int"
+	case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "REAL":
+		return "float"
+	case "NUMERIC", "DECIMAL":
+		return "decimal"
+	case "BOOL", "BOOLEAN":
+		return "bool"
+	case "JSON", "JSONB":
+		return "json"
+	case "DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		return "time"
+	case "BYTEA", "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BINARY", "VARBINARY", "BIT":
+		return "binary"
+	}
+
+	return "string"
+}
+
+func isNumber(s string) bool {
+	if s == "" || !(s[0] == '-' || ('0' <= s[0] && s[0] <= '9')) {
+		return false
+	}
+	return json.Valid([]byte(s))
+}
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time value %q", s)
+}
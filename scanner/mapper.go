@@ -0,0 +1,25 @@
+package scanner
+
+import (
+	"context"
+)
+
+// ColumnNameMapper maps column name of result to column name of field,
+// like mapping "userName" of raw query to "f_user_name"
+type ColumnNameMapper func(column string) string
+
+type contextKeyColumnNameMapper struct{}
+
+// ContextWithColumnNameMapper set mapper of column names for scanning,
+// keys of map target will be mapped too.
+func ContextWithColumnNameMapper(ctx context.Context, mapper ColumnNameMapper) context.Context {
+	return context.WithValue(ctx, contextKeyColumnNameMapper{}, mapper)
+}
+
+// ColumnNameMapperFromContext returns mapper of column names, nil when not set
+func ColumnNameMapperFromContext(ctx context.Context) ColumnNameMapper {
+	if mapper, ok := ctx.Value(contextKeyColumnNameMapper{}).(ColumnNameMapper); ok {
+		return mapper
+	}
+	return nil
+}
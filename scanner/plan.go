@@ -0,0 +1,293 @@
+package scanner
+
+import (
+	"container/list"
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+)
+
+// scanPlan mapping of columns to fields of struct type, resolved once per (column set, type)
+// and reused for every row of result set.
+type scanPlan struct {
+	// fields by column index, nil when column without target
+	fields []*planField
+	// fields not mapped but with ptr struct in path, which should be ensured as ForEachStructFieldValue does
+	unmappedPtrFields []*builder.StructField
+	slices            []*slicePlan
+
+	unmappedColumnIndexes []int
+	missingFields         []string
+}
+
+type planField struct {
+	field     *builder.StructField
+	fieldName string
+	allowNull bool
+}
+
+type slicePlan struct {
+	field     *builder.SliceStructField
+	elemType  reflect.Type
+	isPtrElem bool
+	fields    []*slicePlanField
+	children  []*slicePlan
+}
+
+type slicePlanField struct {
+	columnIndex int
+	planField
+}
+
+type scanPlanKey struct {
+	tpe     reflect.Type
+	columns string
+	// table name and alias from context affect column names of fields
+	tableName  string
+	tableAlias string
+}
+
+// scanPlanCacheSize max count of cached scan plans,
+// columns of user-defined queries are unbounded, the least recently used plan will be dropped.
+const scanPlanCacheSize = 1024
+
+var scanPlans = newScanPlanCache(scanPlanCacheSize)
+
+func scanPlanFor(ctx context.Context, tpe reflect.Type, columns []string) *scanPlan {
+	key := scanPlanKey{
+		tpe:        tpe,
+		columns:    strings.Join(columns, ","),
+		tableName:  builder.TableNameFromContext(ctx),
+		tableAlias: builder.TableAliasFromContext(ctx),
+	}
+
+	if plan, ok := scanPlans.Get(key); ok {
+		return plan
+	}
+
+	return scanPlans.Add(key, newScanPlan(ctx, tpe, columns))
+}
+
+func newScanPlanCache(size int) *scanPlanCache {
+	return &scanPlanCache{
+		size:  size,
+		lru:   list.New(),
+		items: map[scanPlanKey]*list.Element{},
+	}
+}
+
+// scanPlanCache LRU cache of scan plans
+type scanPlanCache struct {
+	size  int
+	mu    sync.Mutex
+	lru   *list.List
+	items map[scanPlanKey]*list.Element
+}
+
+type scanPlanEntry struct {
+	key  scanPlanKey
+	plan *scanPlan
+}
+
+func (c *scanPlanCache) Get(key scanPlanKey) (*scanPlan, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*scanPlanEntry).plan, true
+	}
+	return nil, false
+}
+
+// Add caches plan of key and returns the cached one,
+// when plan of key is added by others during resolving, the existing one will be kept.
+func (c *scanPlanCache) Add(key scanPlanKey, plan *scanPlan) *scanPlan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.lru.MoveToFront(elem)
+		return elem.Value.(*scanPlanEntry).plan
+	}
+
+	c.items[key] = c.lru.PushFront(&scanPlanEntry{key: key, plan: plan})
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.items, oldest.Value.(*scanPlanEntry).key)
+	}
+
+	return plan
+}
+
+func (c *scanPlanCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+func newScanPlan(ctx context.Context, tpe reflect.Type, columns []string) *scanPlan {
+	plan := &scanPlan{
+		fields:        make([]*planField, len(columns)),
+		missingFields: make([]string, 0),
+	}
+
+	columnIndexes := map[string]int{}
+
+	for i, columnName := range columns {
+		columnIndexes[strings.ToLower(columnName)] = i
+	}
+
+	// table names of fields are resolved by a zero value, same as the new item of each row
+	builder.ForEachStructFieldValue(ctx, reflect.New(tpe).Interface(), func(sf *builder.StructFieldValue) {
+		f := sf.Field
+		matched := false
+
+		if sf.TableName != "" {
+			if i, ok := columnIndexes[sf.TableName+"__"+f.Name]; ok && i > -1 {
+				plan.fields[i] = &planField{field: &f, fieldName: f.FieldName, allowNull: f.TableAlias != ""}
+				matched = true
+			}
+		}
+
+		// fields of nested struct tagged with alias only receive aliased columns
+		if f.TableAlias == "" {
+			if i, ok := columnIndexes[f.Name]; ok && i > -1 {
+				plan.fields[i] = &planField{field: &f, fieldName: f.FieldName}
+				matched = true
+			}
+		}
+
+		if !matched {
+			plan.missingFields = append(plan.missingFields, f.FieldName+"("+f.Name+")")
+
+			if hasPtrInPath(tpe, f.Loc) {
+				plan.unmappedPtrFields = append(plan.unmappedPtrFields, &f)
+			}
+		}
+	})
+
+	plan.slices = newSlicePlans(ctx, tpe, columnIndexes, &plan.missingFields)
+
+	for i := range plan.fields {
+		if plan.fields[i] == nil && !isSliceColumn(plan.slices, i) {
+			plan.unmappedColumnIndexes = append(plan.unmappedColumnIndexes, i)
+		}
+	}
+
+	sort.Strings(plan.missingFields)
+
+	return plan
+}
+
+func newSlicePlans(ctx context.Context, tpe reflect.Type, columnIndexes map[string]int, missingFields *[]string) []*slicePlan {
+	sliceFields := builder.SliceStructFieldsFor(tpe)
+	if len(sliceFields) == 0 {
+		return nil
+	}
+
+	plans := make([]*slicePlan, 0, len(sliceFields))
+
+	for _, sf := range sliceFields {
+		p := &slicePlan{
+			field:     sf,
+			elemType:  sf.ElemType,
+			isPtrElem: sf.IsPtrElem,
+		}
+
+		tableAlias := sf.TableAlias
+		fieldPrefix := sf.FieldName + "."
+
+		builder.ForEachStructFieldValue(ctx, reflect.New(sf.ElemType).Interface(), func(field *builder.StructFieldValue) {
+			f := field.Field
+
+			tableName := tableAlias
+			if f.TableAlias != "" {
+				tableName = f.TableAlias
+			}
+
+			if i, ok := columnIndexes[tableName+"__"+f.Name]; ok && i > -1 {
+				p.fields = append(p.fields, &slicePlanField{
+					columnIndex: i,
+					planField:   planField{field: &f, fieldName: fieldPrefix + f.FieldName, allowNull: true},
+				})
+			} else {
+				*missingFields = append(*missingFields, fieldPrefix+f.FieldName+"("+tableName+"__"+f.Name+")")
+			}
+		})
+
+		p.children = newSlicePlans(ctx, sf.ElemType, columnIndexes, missingFields)
+
+		plans = append(plans, p)
+	}
+
+	return plans
+}
+
+func isSliceColumn(plans []*slicePlan, columnIndex int) bool {
+	for _, p := range plans {
+		for _, f := range p.fields {
+			if f.columnIndex == columnIndex {
+				return true
+			}
+		}
+		if isSliceColumn(p.children, columnIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPtrInPath returns whether any struct ptr in the path of field, which will be created when resolving field value
+func hasPtrInPath(tpe reflect.Type, loc []int) bool {
+	for i := 0; i < len(loc)-1; i++ {
+		f := tpe.Field(loc[i])
+		if f.Type.Kind() == reflect.Ptr {
+			return true
+		}
+		tpe = f.Type
+	}
+	return false
+}
+
+// bind binds fields of item rv to dest by plan
+func (p *scanPlan) bind(rv reflect.Value, strict bool, columns []string, dest []interface{}, holder interface{}) []*sliceBinding {
+	for _, f := range p.unmappedPtrFields {
+		f.FieldValue(rv)
+	}
+
+	for i, f := range p.fields {
+		if f == nil {
+			dest[i] = holder
+			continue
+		}
+		dest[i] = newFieldScanner(strict, f.field.FieldValue(rv).Addr().Interface(), columns[i], f.fieldName, f.allowNull)
+	}
+
+	return bindSlicePlans(p.slices, rv, strict, columns, dest)
+}
+
+// strictScanError returns StrictScanError with names of unmapped columns as returned by database
+func (p *scanPlan) strictScanError(v interface{}, columns []string) error {
+	if len(p.unmappedColumnIndexes) == 0 && len(p.missingFields) == 0 {
+		return nil
+	}
+
+	unmappedColumns := make([]string, len(p.unmappedColumnIndexes))
+	for i, idx := range p.unmappedColumnIndexes {
+		unmappedColumns[i] = columns[idx]
+	}
+
+	return &StrictScanError{
+		Type:            reflect.TypeOf(v).String(),
+		UnmappedColumns: unmappedColumns,
+		MissingFields:   p.missingFields,
+	}
+}
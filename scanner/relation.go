@@ -0,0 +1,240 @@
+package scanner
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	typesx "github.com/go-courier/x/types"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+)
+
+// sliceBinding binds columns aliased as <table>__<column> to a new elem of slice field for one-to-many relation,
+// the elem will be appended when any of its columns is not null.
+type sliceBinding struct {
+	field     reflect.Value
+	elem      reflect.Value
+	isPtrElem bool
+	notNull   bool
+	children  []*sliceBinding
+}
+
+func bindSlicePlans(plans []*slicePlan, rv reflect.Value, strict bool, columns []string, dest []interface{}) []*sliceBinding {
+	if len(plans) == 0 {
+		return nil
+	}
+
+	bindings := make([]*sliceBinding, 0, len(plans))
+
+	for _, p := range plans {
+		b := &sliceBinding{
+			field:     p.field.FieldValue(rv),
+			elem:      reflect.New(p.elemType),
+			isPtrElem: p.isPtrElem,
+		}
+
+		for _, f := range p.fields {
+			dest[f.columnIndex] = &notNullScanner{
+				Scanner: newFieldScanner(strict, f.field.FieldValue(b.elem).Addr().Interface(), columns[f.columnIndex], f.fieldName, f.allowNull),
+				notNull: &b.notNull,
+			}
+		}
+
+		b.children = bindSlicePlans(p.children, b.elem, strict, columns, dest)
+
+		bindings = append(bindings, b)
+	}
+
+	return bindings
+}
+
+func appendSliceBindings(bindings []*sliceBinding) {
+	for _, b := range bindings {
+		appendSliceBindings(b.children)
+
+		if !b.notNull {
+			continue
+		}
+
+		if b.isPtrElem {
+			b.field.Set(reflect.Append(b.field, b.elem))
+		} else {
+			b.field.Set(reflect.Append(b.field, b.elem.Elem()))
+		}
+	}
+}
+
+type notNullScanner struct {
+	sql.Scanner
+	notNull *bool
+}
+
+func (s *notNullScanner) Scan(src interface{}) error {
+	if src != nil {
+		*s.notNull = true
+	}
+	return s.Scanner.Scan(src)
+}
+
+// relationGroup merges rows of struct with same primary key into one item,
+// and merges elems of slice fields for one-to-many relation recursively.
+type relationGroup struct {
+	indexes  map[string]int
+	children [][]*relationGroup
+}
+
+func newRelationGroup() *relationGroup {
+	return &relationGroup{indexes: map[string]int{}}
+}
+
+func (g *relationGroup) merge(list reflect.Value, item reflect.Value) {
+	plan := relationPlanFor(item.Type())
+
+	key, hasKey := plan.keyOf(item)
+
+	idx := -1
+
+	if hasKey {
+		if i, ok := g.indexes[key]; ok {
+			idx = i
+		}
+	}
+
+	elems := make([]reflect.Value, len(plan.sliceFields))
+
+	for i, sf := range plan.sliceFields {
+		fv := sf.FieldValue(item)
+		// copy of the slice header
+		elems[i] = reflect.ValueOf(fv.Interface())
+		if idx == -1 {
+			// detach elems, which will be merged into the appended item
+			fv.Set(reflect.Zero(fv.Type()))
+		}
+	}
+
+	if idx == -1 {
+		if list.Type().Elem().Kind() == reflect.Ptr {
+			ptr := reflect.New(item.Type())
+			ptr.Elem().Set(item)
+			list.Set(reflect.Append(list, ptr))
+		} else {
+			list.Set(reflect.Append(list, item))
+		}
+
+		idx = list.Len() - 1
+
+		if hasKey {
+			g.indexes[key] = idx
+		}
+
+		g.children = append(g.children, make([]*relationGroup, len(plan.sliceFields)))
+	}
+
+	parent := list.Index(idx)
+	if parent.Kind() == reflect.Ptr {
+		parent = parent.Elem()
+	}
+
+	for i, sf := range plan.sliceFields {
+		if elems[i].Len() == 0 {
+			continue
+		}
+
+		child := g.children[idx][i]
+		if child == nil {
+			child = newRelationGroup()
+			g.children[idx][i] = child
+		}
+
+		field := sf.FieldValue(parent)
+
+		for j := 0; j < elems[i].Len(); j++ {
+			elem := elems[i].Index(j)
+			if elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			child.merge(field, elem)
+		}
+	}
+}
+
+type relationPlan struct {
+	primaryKeyFields []*builder.StructField
+	sliceFields      []*builder.SliceStructField
+}
+
+// keyOf returns key of the item by values of primary key, false when without primary key
+func (p *relationPlan) keyOf(item reflect.Value) (string, bool) {
+	if len(p.primaryKeyFields) == 0 {
+		return "", false
+	}
+
+	// values formatted with %#v, strings are quoted, for no collision of composite primary key
+	b := strings.Builder{}
+	for i, f := range p.primaryKeyFields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		_, _ = fmt.Fprintf(&b, "%#v", f.FieldValue(item).Interface())
+	}
+
+	return b.String(), true
+}
+
+var relationPlans = sync.Map{}
+
+func relationPlanFor(tpe reflect.Type) *relationPlan {
+	if v, ok := relationPlans.Load(tpe); ok {
+		return v.(*relationPlan)
+	}
+
+	plan := &relationPlan{
+		sliceFields: builder.SliceStructFieldsFor(tpe),
+	}
+
+	if m, ok := reflect.New(tpe).Interface().(builder.Model); ok {
+		table := builder.TableFromModel(m)
+
+		table.Keys.Range(func(key *builder.Key, idx int) {
+			if plan.primaryKeyFields != nil || !key.IsPrimary() {
+				return
+			}
+
+			fields := builder.StructFieldsFor(context.Background(), typesx.FromRType(tpe))
+			fieldNames := key.FieldNames()
+
+			primaryKeyFields := make([]*builder.StructField, 0, len(fieldNames))
+
+			for _, fieldName := range fieldNames {
+				for _, f := range fields {
+					if f.FieldName == fieldName && f.TableAlias == "" {
+						primaryKeyFields = append(primaryKeyFields, f)
+						break
+					}
+				}
+			}
+
+			if len(primaryKeyFields) == len(fieldNames) {
+				plan.primaryKeyFields = primaryKeyFields
+			}
+		})
+	}
+
+	relationPlans.Store(tpe, plan)
+
+	return plan
+}
+
+// hasSliceStructFields returns whether elems of slice should be grouped for one-to-many relation
+func hasSliceStructFields(tpe reflect.Type) bool {
+	return len(builder.SliceStructFieldsFor(tpe)) > 0
+}
+
+// errSliceRelation rows of one-to-many relation could only be grouped when scanning into slice,
+// scanning row by row, like Cursor and Each, will get partial items.
+func errSliceRelation(tpe reflect.Type) error {
+	return fmt.Errorf("%s with slice fields of one-to-many relation could not be scanned row by row, scan into slice instead", tpe)
+}
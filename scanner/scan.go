@@ -20,10 +20,12 @@ func Scan(ctx context.Context, rows *sql.Rows, v interface{}) error {
 		return err
 	}
 
+	s := newRowScanner(ctx, rows)
+
 	for rows.Next() {
 		item := si.New()
 
-		if scanErr := scanTo(context.Background(), rows, item); scanErr != nil {
+		if scanErr := s.scanTo(item); scanErr != nil {
 			return scanErr
 		}
 
@@ -2,9 +2,15 @@ package scanner
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/scanner/nullable"
 	"github.com/onsi/gomega"
 )
 
@@ -216,3 +222,643 @@ func TestScan(t *testing.T) {
 		}))
 	})
 }
+
+func TestEach(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	t.Run("Each row", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(2, "2")
+		mockRows.AddRow(3, "3")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, err := db.Query("SELECT f_i,f_s from t")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		list := make([]T, 0)
+
+		err = Each(context.Background(), rows, func(row T) error {
+			list = append(list, row)
+			return nil
+		})
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.Equal([]T{{I: 2, S: "2"}, {I: 3, S: "3"}}))
+	})
+
+	t.Run("Each row stopped", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(2, "2")
+		mockRows.AddRow(3, "3")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows).RowsWillBeClosed()
+
+		rows, err := db.Query("SELECT f_i,f_s from t")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		list := make([]*T, 0)
+
+		err = Each(context.Background(), rows, func(row *T) error {
+			list = append(list, row)
+			return StopIteration
+		})
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.Equal([]*T{{I: 2, S: "2"}}))
+		gomega.NewWithT(t).Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("Each with bad fn", func(t *testing.T) {
+		err := Each(context.Background(), nil, func(row T) {})
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+	})
+
+	t.Run("Each with bad fn should close rows", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(2, "2")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows).RowsWillBeClosed()
+
+		rows, err := db.Query("SELECT f_i,f_s from t")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		err = Each(context.Background(), rows, func(row T) {})
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(2, "2")
+		mockRows.AddRow(3, "3")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, err := db.Query("SELECT f_i,f_s from t")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+		c := NewCursor(context.Background(), rows)
+		defer c.Close()
+
+		list := make([]T, 0)
+
+		for c.Next() {
+			v := T{}
+			gomega.NewWithT(t).Expect(c.Scan(&v)).To(gomega.BeNil())
+			list = append(list, v)
+		}
+
+		gomega.NewWithT(t).Expect(c.Err()).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+	})
+}
+
+type Org struct {
+	ID   uint64 `db:"f_id"`
+	Name string `db:"f_name"`
+}
+
+func (Org) TableName() string {
+	return "t_org"
+}
+
+func (Org) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+type Tag struct {
+	Name string `db:"f_name"`
+}
+
+type OrgWithTags struct {
+	Org
+	Tags []Tag `alias:"t_tag"`
+}
+
+type User struct {
+	ID   uint64 `db:"f_id"`
+	Name string `db:"f_name"`
+}
+
+func (User) TableName() string {
+	return "t_user"
+}
+
+func (User) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+type UserWithOrgs struct {
+	User
+	Leader *User         `alias:"t_leader"`
+	Orgs   []OrgWithTags `alias:"t_org"`
+}
+
+func TestScanRelations(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mockRows := mock.NewRows([]string{
+		"t_user__f_id", "t_user__f_name",
+		"t_leader__f_id", "t_leader__f_name",
+		"t_org__f_id", "t_org__f_name",
+		"t_tag__f_name",
+	})
+	mockRows.AddRow(1, "a", 10, "leader", 1, "org1", "x")
+	mockRows.AddRow(1, "a", 10, "leader", 1, "org1", "y")
+	mockRows.AddRow(1, "a", 10, "leader", 2, "org2", nil)
+	mockRows.AddRow(2, "b", 10, "leader", nil, nil, nil)
+	mockRows.AddRow(1, "a", 10, "leader", 3, "org3", "z")
+
+	_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+
+	rows, err := db.Query("SELECT * from t_user")
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	list := make([]UserWithOrgs, 0)
+
+	err = Scan(context.Background(), rows, &list)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	gomega.NewWithT(t).Expect(list).To(gomega.Equal([]UserWithOrgs{
+		{
+			User:   User{ID: 1, Name: "a"},
+			Leader: &User{ID: 10, Name: "leader"},
+			Orgs: []OrgWithTags{
+				{Org: Org{ID: 1, Name: "org1"}, Tags: []Tag{{Name: "x"}, {Name: "y"}}},
+				{Org: Org{ID: 2, Name: "org2"}},
+				{Org: Org{ID: 3, Name: "org3"}, Tags: []Tag{{Name: "z"}}},
+			},
+		},
+		{
+			User:   User{ID: 2, Name: "b"},
+			Leader: &User{ID: 10, Name: "leader"},
+		},
+	}))
+}
+
+type Membership struct {
+	Group string `db:"f_group"`
+	Name  string `db:"f_name"`
+}
+
+func (Membership) TableName() string {
+	return "t_membership"
+}
+
+func (Membership) PrimaryKey() []string {
+	return []string{"Group", "Name"}
+}
+
+type MembershipWithTags struct {
+	Membership
+	Tags []Tag `alias:"t_tag"`
+}
+
+func TestScanRelationsWithCompositePrimaryKey(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mockRows := mock.NewRows([]string{"t_membership__f_group", "t_membership__f_name", "t_tag__f_name"})
+	mockRows.AddRow("a b", "c", "x")
+	mockRows.AddRow("a", "b c", "y")
+	mockRows.AddRow("a b", "c", "z")
+
+	_ = mock.ExpectQuery("SELECT .+ from t_membership").WillReturnRows(mockRows)
+
+	rows, err := db.Query("SELECT * from t_membership")
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	list := make([]MembershipWithTags, 0)
+
+	err = Scan(context.Background(), rows, &list)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	gomega.NewWithT(t).Expect(list).To(gomega.Equal([]MembershipWithTags{
+		{Membership: Membership{Group: "a b", Name: "c"}, Tags: []Tag{{Name: "x"}, {Name: "z"}}},
+		{Membership: Membership{Group: "a", Name: "b c"}, Tags: []Tag{{Name: "y"}}},
+	}))
+}
+
+func TestScanRelationsRowByRow(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	newRows := func() *sql.Rows {
+		mockRows := mock.NewRows([]string{"t_user__f_id", "t_user__f_name", "t_org__f_id", "t_org__f_name"})
+		mockRows.AddRow(1, "a", 1, "org1")
+		mockRows.AddRow(1, "a", 2, "org2")
+
+		_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows).RowsWillBeClosed()
+
+		rows, err := db.Query("SELECT * from t_user")
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		return rows
+	}
+
+	t.Run("Each", func(t *testing.T) {
+		err := Each(context.Background(), newRows(), func(u *UserWithOrgs) error {
+			return nil
+		})
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		c := NewCursor(context.Background(), newRows())
+
+		gomega.NewWithT(t).Expect(c.Next()).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(c.Scan(&UserWithOrgs{})).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(c.Close()).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(mock.ExpectationsWereMet()).To(gomega.BeNil())
+	})
+}
+
+func TestScanStrict(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	t.Run("Scan to struct", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(2, "4")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		target := &T{}
+		rows, _ := db.Query("SELECT f_i,f_s from t")
+		err := ScanStrict(context.Background(), rows, target)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(target).To(gomega.Equal(&T{I: 2, S: "4"}))
+	})
+
+	t.Run("Scan with unmapped columns and missing fields", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_other"})
+		mockRows.AddRow(2, "4")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, _ := db.Query("SELECT f_i,f_other from t")
+		err := ScanStrict(context.Background(), rows, &T{})
+
+		strictErr, ok := err.(*StrictScanError)
+		gomega.NewWithT(t).Expect(ok).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(strictErr.UnmappedColumns).To(gomega.Equal([]string{"f_other"}))
+		gomega.NewWithT(t).Expect(strictErr.MissingFields).To(gomega.Equal([]string{"S(f_s)"}))
+		gomega.NewWithT(t).Expect(err.Error()).To(gomega.Equal("strict scan to *scanner.T failed, unmapped columns: f_other, missing fields: S(f_s)"))
+	})
+
+	t.Run("Scan with column receivers", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i"})
+		mockRows.AddRow(2)
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, _ := db.Query("SELECT f_i from t")
+		err := Scan(ContextWithScanStrict(context.Background(), true), rows, &T2{})
+
+		strictErr, ok := err.(*StrictScanError)
+		gomega.NewWithT(t).Expect(ok).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(strictErr.MissingFields).To(gomega.Equal([]string{"f_s"}))
+	})
+
+	t.Run("Scan NULL to non-nullable field", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(nil, "4")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, _ := db.Query("SELECT f_i,f_s from t")
+		err := ScanStrict(context.Background(), rows, &T{})
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(err.Error()).To(gomega.ContainSubstring("NULL could not be assigned to int"))
+	})
+
+	t.Run("Scan incompatible value", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(time.Now(), "4")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		rows, _ := db.Query("SELECT f_i,f_s from t")
+		err := ScanStrict(context.Background(), rows, &T{})
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(err.Error()).To(gomega.ContainSubstring("time.Time could not be assigned to int"))
+	})
+
+	t.Run("Scan in normal mode ignores unmatched", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_other"})
+		mockRows.AddRow(nil, "4")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		target := &T{}
+		rows, _ := db.Query("SELECT f_i,f_other from t")
+		err := Scan(context.Background(), rows, target)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(target).To(gomega.Equal(&T{}))
+	})
+}
+
+func TestScanToMap(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	newRows := func() *sqlmock.Rows {
+		mockRows := mock.NewRowsWithColumnDefinition(
+			sqlmock.NewColumn("f_id").OfType("INT8", int64(0)),
+			sqlmock.NewColumn("f_amount").OfType("NUMERIC", []byte{}),
+			sqlmock.NewColumn("f_meta").OfType("JSONB", []byte{}),
+			sqlmock.NewColumn("f_created_at").OfType("TIMESTAMPTZ", []byte{}),
+			sqlmock.NewColumn("f_name").OfType("VARCHAR", []byte{}),
+			sqlmock.NewColumn("f_remark").OfType("TEXT", nil),
+		)
+		mockRows.AddRow(int64(1), []byte("12.50"), []byte(`{"a":1}`), []byte("2020-01-02 03:04:05Z"), []byte("a"), nil)
+		mockRows.AddRow(int64(2), []byte("3"), []byte(`[1]`), createdAt, []byte("b"), []byte("x"))
+		return mockRows
+	}
+
+	t.Run("Scan to slice of map", func(t *testing.T) {
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows())
+
+		rows, _ := db.Query("SELECT * from t")
+
+		list := make([]map[string]interface{}, 0)
+		err := Scan(context.Background(), rows, &list)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.Equal([]map[string]interface{}{
+			{
+				"f_id":         int64(1),
+				"f_amount":     json.Number("12.50"),
+				"f_meta":       map[string]interface{}{"a": float64(1)},
+				"f_created_at": createdAt,
+				"f_name":       "a",
+				"f_remark":     nil,
+			},
+			{
+				"f_id":         int64(2),
+				"f_amount":     json.Number("3"),
+				"f_meta":       []interface{}{float64(1)},
+				"f_created_at": createdAt,
+				"f_name":       "b",
+				"f_remark":     "x",
+			},
+		}))
+	})
+
+	t.Run("Scan to map", func(t *testing.T) {
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows())
+
+		rows, _ := db.Query("SELECT * from t")
+
+		m := map[string]interface{}{}
+		err := Scan(context.Background(), rows, &m)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(m["f_id"]).To(gomega.Equal(int64(2)))
+	})
+
+	t.Run("Scan to rows", func(t *testing.T) {
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows())
+
+		rows, _ := db.Query("SELECT * from t")
+
+		list := make([]Row, 0)
+		err := Scan(context.Background(), rows, &list)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+
+		row := list[0]
+		gomega.NewWithT(t).Expect(row.Int64("f_id")).To(gomega.Equal(int64(1)))
+		gomega.NewWithT(t).Expect(row.Float64("f_amount")).To(gomega.Equal(12.5))
+		gomega.NewWithT(t).Expect(row.Int64("f_amount")).To(gomega.Equal(int64(12)))
+		gomega.NewWithT(t).Expect(row.String("f_amount")).To(gomega.Equal("12.50"))
+		gomega.NewWithT(t).Expect(row.Time("f_created_at")).To(gomega.Equal(createdAt))
+		gomega.NewWithT(t).Expect(row.String("f_name")).To(gomega.Equal("a"))
+		gomega.NewWithT(t).Expect(row.IsNull("f_remark")).To(gomega.BeTrue())
+		gomega.NewWithT(t).Expect(row.String("f_remark")).To(gomega.Equal(""))
+	})
+}
+
+func TestConvertColumnValue(t *testing.T) {
+	cases := []struct {
+		databaseTypeName string
+		v                interface{}
+		expect           interface{}
+	}{
+		{"UNSIGNED BIGINT", []byte("10"), int64(10)},
+		{"DECIMAL", []byte("1.25"), json.Number("1.25")},
+		{"NUMERIC", []byte("12345678901234567890.123456789"), json.Number("12345678901234567890.123456789")},
+		{"NUMERIC", []byte("NaN"), "NaN"},
+		{"MONEY", []byte("$1,234.50"), "$1,234.50"},
+		{"DOUBLE", []byte("1.25"), 1.25},
+		{"BOOL", []byte("t"), true},
+		{"TINYINT", int64(1), int64(1)},
+		{"DATE", []byte("2020-01-02"), time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"TIME", []byte("10:00:00"), "10:00:00"},
+		{"BLOB", []byte("x"), []byte("x")},
+		{"VARCHAR(255)", []byte("x"), "x"},
+	}
+
+	for _, c := range cases {
+		v, err := ConvertColumnValue(c.databaseTypeName, c.v)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(v).To(gomega.Equal(c.expect))
+	}
+
+	v, err := ConvertColumnValue("TEXT", nil)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(v).To(gomega.BeNil())
+}
+
+type TWide struct {
+	ID        uint64  `db:"f_id"`
+	Name      string  `db:"f_name"`
+	Nickname  string  `db:"f_nickname"`
+	Age       int     `db:"f_age"`
+	Score     float64 `db:"f_score"`
+	Enabled   bool    `db:"f_enabled"`
+	CreatedAt int64   `db:"f_created_at"`
+	UpdatedAt int64   `db:"f_updated_at"`
+}
+
+// scanToPerRow the legacy scanTo of struct, which resolves columns and walks struct fields for every row,
+// kept as baseline of BenchmarkScanList
+func scanToPerRow(ctx context.Context, rows *sql.Rows, v interface{}) error {
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	dest := make([]interface{}, len(columns))
+	holder := placeholder()
+
+	columnIndexes := map[string]int{}
+
+	for i, columnName := range columns {
+		columnIndexes[strings.ToLower(columnName)] = i
+		dest[i] = holder
+	}
+
+	builder.ForEachStructFieldValue(ctx, v, func(sf *builder.StructFieldValue) {
+		if sf.TableName != "" {
+			if i, ok := columnIndexes[sf.TableName+"__"+sf.Field.Name]; ok && i > -1 {
+				dest[i] = nullable.NewNullIgnoreScanner(sf.Value.Addr().Interface())
+			}
+		}
+
+		if i, ok := columnIndexes[sf.Field.Name]; ok && i > -1 {
+			dest[i] = nullable.NewNullIgnoreScanner(sf.Value.Addr().Interface())
+		}
+	})
+
+	return rows.Scan(dest...)
+}
+
+func BenchmarkScanList(b *testing.B) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	columns := []string{"f_id", "f_name", "f_nickname", "f_age", "f_score", "f_enabled", "f_created_at", "f_updated_at"}
+
+	newRows := func(n int) *sqlmock.Rows {
+		mockRows := mock.NewRows(columns)
+		for i := 0; i < n; i++ {
+			mockRows.AddRow(i, "name", "nickname", 18, 9.5, true, 1600000000, 1600000000)
+		}
+		return mockRows
+	}
+
+	b.Run("Scan 1000 rows to slice of struct", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows(1000))
+			rows, _ := db.Query("SELECT * from t")
+			list := make([]TWide, 0)
+			b.StartTimer()
+
+			if err := Scan(context.Background(), rows, &list); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Scan 1000 rows to slice of struct row by row without scan plan", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows(1000))
+			rows, _ := db.Query("SELECT * from t")
+			list := make([]TWide, 0)
+			b.StartTimer()
+
+			for rows.Next() {
+				item := TWide{}
+				if err := scanToPerRow(context.Background(), rows, &item); err != nil {
+					b.Fatal(err)
+				}
+				list = append(list, item)
+			}
+			_ = rows.Close()
+		}
+	})
+
+	b.Run("Scan 1000 rows to slice of struct in strict mode", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(newRows(1000))
+			rows, _ := db.Query("SELECT * from t")
+			list := make([]TWide, 0)
+			b.StartTimer()
+
+			if err := ScanStrict(context.Background(), rows, &list); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Scan 1000 joined rows to slice of struct with relations", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			mockRows := mock.NewRows([]string{"t_user__f_id", "t_user__f_name", "t_leader__f_id", "t_leader__f_name", "t_org__f_id", "t_org__f_name", "t_tag__f_name"})
+			for j := 0; j < 1000; j++ {
+				mockRows.AddRow(j/10, "a", 10, "leader", j, "org", "x")
+			}
+			_ = mock.ExpectQuery("SELECT .+ from t_user").WillReturnRows(mockRows)
+			rows, _ := db.Query("SELECT * from t_user")
+			list := make([]UserWithOrgs, 0)
+			b.StartTimer()
+
+			if err := Scan(context.Background(), rows, &list); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestScanWithContext(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	t.Run("Scan canceled between rows", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"f_i", "f_s"})
+		mockRows.AddRow(1, "a")
+		mockRows.AddRow(2, "b")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		list := make([]T, 0)
+
+		si, _ := ScanIteratorFromFunc(func(item T) error {
+			list = append(list, item)
+			cancel()
+			return nil
+		})
+
+		rows, _ := db.Query("SELECT f_i,f_s from t")
+		err := Scan(ctx, rows, si)
+		gomega.NewWithT(t).Expect(err).To(gomega.Equal(context.Canceled))
+		gomega.NewWithT(t).Expect(list).To(gomega.Equal([]T{{I: 1, S: "a"}}))
+	})
+
+	t.Run("Scan with table name from context", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"t__f_i", "t__f_s"})
+		mockRows.AddRow(1, "a")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		target := &T{}
+		rows, _ := db.Query("SELECT t.f_i,t.f_s from t")
+		err := Scan(builder.WithTableName("t")(context.Background()), rows, target)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(target).To(gomega.Equal(&T{I: 1, S: "a"}))
+	})
+
+	t.Run("Scan with column name mapper", func(t *testing.T) {
+		mockRows := mock.NewRows([]string{"i", "s"})
+		mockRows.AddRow(1, "a")
+
+		_ = mock.ExpectQuery("SELECT .+ from t").WillReturnRows(mockRows)
+
+		ctx := ContextWithColumnNameMapper(context.Background(), func(column string) string {
+			return "f_" + column
+		})
+		ctx = ContextWithScanStrict(ctx, true)
+
+		list := make([]T, 0)
+		rows, _ := db.Query("SELECT f_i AS i,f_s AS s from t")
+		err := Scan(ctx, rows, &list)
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(list).To(gomega.Equal([]T{{I: 1, S: "a"}}))
+	})
+}
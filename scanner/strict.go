@@ -0,0 +1,148 @@
+package scanner
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/kunlun-qilian/sqlx/v3/scanner/nullable"
+)
+
+type contextKeyScanStrict struct{}
+
+// ContextWithScanStrict enable or disable strict mode of scanning
+func ContextWithScanStrict(ctx context.Context, strict bool) context.Context {
+	return context.WithValue(ctx, contextKeyScanStrict{}, strict)
+}
+
+// ScanStrictFromContext returns whether strict mode of scanning enabled
+func ScanStrictFromContext(ctx context.Context) bool {
+	if strict, ok := ctx.Value(contextKeyScanStrict{}).(bool); ok {
+		return strict
+	}
+	return false
+}
+
+// ScanStrict scan rows to v as Scan in strict mode,
+// which returns StrictScanError when columns without target or db-tagged fields without column,
+// and returns error when scanned value could not be assigned to target without loss, like NULL to non-nullable field.
+func ScanStrict(ctx context.Context, rows *sql.Rows, v interface{}) error {
+	return Scan(ContextWithScanStrict(ctx, true), rows, v)
+}
+
+// StrictScanError error of strict mode when columns not matched fields
+type StrictScanError struct {
+	// Type of target
+	Type string
+	// UnmappedColumns columns of result without target
+	UnmappedColumns []string
+	// MissingFields db-tagged fields without column, as FieldName(column)
+	MissingFields []string
+}
+
+func (e *StrictScanError) Error() string {
+	b := strings.Builder{}
+	b.WriteString("strict scan to ")
+	b.WriteString(e.Type)
+	b.WriteString(" failed")
+	if len(e.UnmappedColumns) > 0 {
+		b.WriteString(", unmapped columns: ")
+		b.WriteString(strings.Join(e.UnmappedColumns, ", "))
+	}
+	if len(e.MissingFields) > 0 {
+		b.WriteString(", missing fields: ")
+		b.WriteString(strings.Join(e.MissingFields, ", "))
+	}
+	return b.String()
+}
+
+// newFieldScanner create scanner of field for column,
+// NULL will be ignored in normal mode, and values will be validated in strict mode.
+func newFieldScanner(strict bool, dest interface{}, column string, fieldName string, allowNull bool) sql.Scanner {
+	if !strict {
+		return nullable.NewNullIgnoreScanner(dest)
+	}
+	return &strictScanner{
+		dest:      dest,
+		column:    column,
+		fieldName: fieldName,
+		allowNull: allowNull,
+	}
+}
+
+type strictScanner struct {
+	dest      interface{}
+	column    string
+	fieldName string
+	// allow NULL for non-nullable field, like columns of LEFT JOIN
+	allowNull bool
+}
+
+func (s *strictScanner) Scan(src interface{}) error {
+	if _, ok := s.dest.(sql.Scanner); !ok {
+		if err := s.validate(src); err != nil {
+			return err
+		}
+	}
+	if err := nullable.NewNullIgnoreScanner(s.dest).Scan(src); err != nil {
+		return fmt.Errorf("scan column %s to field %s failed: %s", s.column, s.fieldName, err)
+	}
+	return nil
+}
+
+var typeTime = reflect.TypeOf(time.Time{})
+
+func (s *strictScanner) validate(src interface{}) error {
+	tpe := reflect.TypeOf(s.dest).Elem()
+
+	if src == nil {
+		switch tpe.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			return nil
+		}
+		if s.allowNull {
+			return nil
+		}
+		return fmt.Errorf("scan column %s to field %s failed: NULL could not be assigned to %s", s.column, s.fieldName, tpe)
+	}
+
+	for tpe.Kind() == reflect.Ptr {
+		tpe = tpe.Elem()
+	}
+
+	compatible := true
+
+	switch src.(type) {
+	case int64:
+		switch tpe.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64, reflect.Bool, reflect.Interface:
+		default:
+			compatible = false
+		}
+	case float64:
+		switch tpe.Kind() {
+		case reflect.Float32, reflect.Float64, reflect.Interface:
+		default:
+			compatible = false
+		}
+	case bool:
+		switch tpe.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Interface:
+		default:
+			compatible = false
+		}
+	case time.Time:
+		compatible = tpe == typeTime || tpe.Kind() == reflect.Interface
+	}
+
+	if !compatible {
+		return fmt.Errorf("scan column %s to field %s failed: %T could not be assigned to %s", s.column, s.fieldName, src, tpe)
+	}
+
+	return nil
+}
@@ -5,10 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	reflectx "github.com/go-courier/x/reflect"
-	"github.com/kunlun-qilian/sqlx/v3/builder"
 	"github.com/kunlun-qilian/sqlx/v3/scanner/nullable"
 )
 
@@ -20,22 +20,111 @@ type WithColumnReceivers interface {
 	ColumnReceivers() map[string]interface{}
 }
 
+// scanTo scan current row to v, without reusing of columns for following rows
 func scanTo(ctx context.Context, rows *sql.Rows, v interface{}) error {
+	return newRowScanner(ctx, rows).scanTo(v)
+}
+
+// rowScanner scans rows of one result set,
+// columns and scan plan are resolved once and reused for every row.
+type rowScanner struct {
+	ctx    context.Context
+	rows   *sql.Rows
+	strict bool
+	mapper ColumnNameMapper
+	// columns as returned by database
+	columns []string
+	// columns mapped by ColumnNameMapper for matching fields
+	fieldColumns []string
+	columnTypes  []*sql.ColumnType
+	holder       sql.Scanner
+	plan         *scanPlan
+	planType     reflect.Type
+}
+
+func newRowScanner(ctx context.Context, rows *sql.Rows) *rowScanner {
+	return &rowScanner{
+		ctx:    ctx,
+		rows:   rows,
+		strict: ScanStrictFromContext(ctx),
+		mapper: ColumnNameMapperFromContext(ctx),
+		holder: placeholder(),
+	}
+}
+
+// resolveColumns returns columns as returned by database and columns for matching fields
+func (s *rowScanner) resolveColumns() ([]string, []string, error) {
+	if s.columns == nil {
+		columns, err := s.rows.Columns()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		s.columns = columns
+		s.fieldColumns = columns
+
+		if s.mapper != nil {
+			s.fieldColumns = make([]string, len(columns))
+			for i := range columns {
+				s.fieldColumns[i] = s.mapper(columns[i])
+			}
+		}
+	}
+	return s.columns, s.fieldColumns, nil
+}
+
+func (s *rowScanner) resolveColumnTypes() ([]*sql.ColumnType, error) {
+	if s.columnTypes == nil {
+		columnTypes, err := s.rows.ColumnTypes()
+		if err != nil {
+			return nil, err
+		}
+		s.columnTypes = columnTypes
+	}
+	return s.columnTypes, nil
+}
+
+func (s *rowScanner) scanPlanFor(tpe reflect.Type, columns []string) *scanPlan {
+	if s.plan == nil || s.planType != tpe {
+		s.plan = scanPlanFor(s.ctx, tpe, columns)
+		s.planType = tpe
+	}
+	return s.plan
+}
+
+func (s *rowScanner) scanTo(v interface{}) error {
 	tpe := reflect.TypeOf(v)
 
 	if tpe.Kind() != reflect.Ptr {
 		return fmt.Errorf("scanTo target must be a ptr value, but got %T", v)
 	}
 
-	if s, ok := v.(sql.Scanner); ok {
-		return rows.Scan(s)
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	if scanner, ok := v.(sql.Scanner); ok {
+		return s.rows.Scan(scanner)
 	}
 
 	tpe = reflectx.Deref(tpe)
 
 	switch tpe.Kind() {
+	case reflect.Map:
+		if isMapTarget(tpe) {
+			_, fieldColumns, err := s.resolveColumns()
+			if err != nil {
+				return err
+			}
+			columnTypes, err := s.resolveColumnTypes()
+			if err != nil {
+				return err
+			}
+			return scanToMap(s.rows, fieldColumns, columnTypes, reflectx.Indirect(reflect.ValueOf(v)))
+		}
+		return s.rows.Scan(nullable.NewNullIgnoreScanner(v))
 	case reflect.Struct:
-		columns, err := rows.Columns()
+		columns, fieldColumns, err := s.resolveColumns()
 		if err != nil {
 			return err
 		}
@@ -46,44 +135,78 @@ func scanTo(ctx context.Context, rows *sql.Rows, v interface{}) error {
 		}
 
 		dest := make([]interface{}, n)
-		holder := placeholder()
 
 		if withColumnReceivers, ok := v.(WithColumnReceivers); ok {
 			columnReceivers := withColumnReceivers.ColumnReceivers()
 
-			for i, columnName := range columns {
-				if cr, ok := columnReceivers[strings.ToLower(columnName)]; ok {
-					dest[i] = nullable.NewNullIgnoreScanner(cr)
+			matched := map[string]bool{}
+
+			for i, columnName := range fieldColumns {
+				name := strings.ToLower(columnName)
+				if cr, ok := columnReceivers[name]; ok {
+					dest[i] = newFieldScanner(s.strict, cr, columns[i], name, false)
+					matched[name] = true
 				} else {
-					dest[i] = holder
+					dest[i] = s.holder
 				}
 			}
 
-			return rows.Scan(dest...)
+			if s.strict {
+				missingFields := make([]string, 0)
+				for name := range columnReceivers {
+					if !matched[name] {
+						missingFields = append(missingFields, name)
+					}
+				}
+				if err := strictScanErrorOf(v, columns, dest, s.holder, missingFields); err != nil {
+					return err
+				}
+			}
+
+			return s.rows.Scan(dest...)
 		}
 
-		columnIndexes := map[string]int{}
+		plan := s.scanPlanFor(tpe, fieldColumns)
 
-		for i, columnName := range columns {
-			columnIndexes[strings.ToLower(columnName)] = i
-			dest[i] = holder
+		if s.strict {
+			if err := plan.strictScanError(v, columns); err != nil {
+				return err
+			}
 		}
 
-		builder.ForEachStructFieldValue(ctx, v, func(sf *builder.StructFieldValue) {
-			if sf.TableName != "" {
-				if i, ok := columnIndexes[sf.TableName+"__"+sf.Field.Name]; ok && i > -1 {
-					dest[i] = nullable.NewNullIgnoreScanner(sf.Value.Addr().Interface())
-				}
-			}
+		sliceBindings := plan.bind(reflect.ValueOf(v), s.strict, columns, dest, s.holder)
 
-			if i, ok := columnIndexes[sf.Field.Name]; ok && i > -1 {
-				dest[i] = nullable.NewNullIgnoreScanner(sf.Value.Addr().Interface())
-			}
-		})
+		if err := s.rows.Scan(dest...); err != nil {
+			return err
+		}
+
+		appendSliceBindings(sliceBindings)
 
-		return rows.Scan(dest...)
+		return nil
 	default:
-		return rows.Scan(nullable.NewNullIgnoreScanner(v))
+		return s.rows.Scan(nullable.NewNullIgnoreScanner(v))
+	}
+}
+
+func strictScanErrorOf(v interface{}, columns []string, dest []interface{}, holder sql.Scanner, missingFields []string) error {
+	unmappedColumns := make([]string, 0)
+
+	for i := range dest {
+		if dest[i] == holder {
+			unmappedColumns = append(unmappedColumns, columns[i])
+		}
+	}
+
+	if len(unmappedColumns) == 0 && len(missingFields) == 0 {
+		return nil
+	}
+
+	sort.Strings(missingFields)
+
+	return &StrictScanError{
+		Type:            fmt.Sprintf("%T", v),
+		UnmappedColumns: unmappedColumns,
+		MissingFields:   missingFields,
 	}
 }
 
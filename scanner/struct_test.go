@@ -2,11 +2,13 @@ package scanner
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/kunlun-qilian/sqlx/v3/scanner/nullable"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/onsi/gomega"
 )
 
 func BenchmarkScanStruct(b *testing.B) {
@@ -73,3 +75,44 @@ func BenchmarkScanStruct(b *testing.B) {
 		b.Log(target)
 	})
 }
+
+func TestScanPlanFor(t *testing.T) {
+	tpe := reflect.TypeOf(T{})
+
+	plan := scanPlanFor(context.Background(), tpe, []string{"f_s", "f_other"})
+
+	gomega.NewWithT(t).Expect(plan).To(gomega.BeIdenticalTo(scanPlanFor(context.Background(), tpe, []string{"f_s", "f_other"})))
+	gomega.NewWithT(t).Expect(plan.fields[0].fieldName).To(gomega.Equal("S"))
+	gomega.NewWithT(t).Expect(plan.fields[1]).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(plan.unmappedColumnIndexes).To(gomega.Equal([]int{1}))
+	gomega.NewWithT(t).Expect(plan.missingFields).To(gomega.Equal([]string{"I(f_i)"}))
+
+	gomega.NewWithT(t).Expect(plan).NotTo(gomega.BeIdenticalTo(scanPlanFor(context.Background(), tpe, []string{"f_i", "f_s"})))
+}
+
+func TestScanPlanCache(t *testing.T) {
+	c := newScanPlanCache(2)
+
+	keyOf := func(columns string) scanPlanKey {
+		return scanPlanKey{tpe: reflect.TypeOf(T{}), columns: columns}
+	}
+
+	a := c.Add(keyOf("f_i"), &scanPlan{})
+	gomega.NewWithT(t).Expect(c.Add(keyOf("f_i"), &scanPlan{})).To(gomega.BeIdenticalTo(a))
+
+	c.Add(keyOf("f_s"), &scanPlan{})
+
+	// touch f_i, then f_s is the least recently used one
+	_, ok := c.Get(keyOf("f_i"))
+	gomega.NewWithT(t).Expect(ok).To(gomega.BeTrue())
+
+	c.Add(keyOf("f_i,f_s"), &scanPlan{})
+	gomega.NewWithT(t).Expect(c.Len()).To(gomega.Equal(2))
+
+	_, ok = c.Get(keyOf("f_s"))
+	gomega.NewWithT(t).Expect(ok).To(gomega.BeFalse())
+
+	plan, ok := c.Get(keyOf("f_i"))
+	gomega.NewWithT(t).Expect(ok).To(gomega.BeTrue())
+	gomega.NewWithT(t).Expect(plan).To(gomega.BeIdenticalTo(a))
+}
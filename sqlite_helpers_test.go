@@ -0,0 +1,80 @@
+package sqlx_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/migration"
+	"github.com/kunlun-qilian/sqlx/v3/sqliteconnector"
+	. "github.com/onsi/gomega"
+)
+
+type Member struct {
+	ID       uint64 `db:"f_id,autoincrement"`
+	Name     string `db:"f_name,size=255,default=''"`
+	Nickname string `db:"f_nickname,size=255,default=''"`
+	Age      int32  `db:"f_age,default='0'"`
+}
+
+func (Member) TableName() string {
+	return "t_member"
+}
+
+func (Member) PrimaryKey() []string {
+	return []string{"ID"}
+}
+
+func (Member) UniqueIndexes() builder.Indexes {
+	return builder.Indexes{
+		"i_name": {"Name"},
+	}
+}
+
+// newSQLiteConnector connector of new sqlite database file for testing
+func newSQLiteConnector(t *testing.T) *sqliteconnector.SQLiteConnector {
+	return &sqliteconnector.SQLiteConnector{File: filepath.Join(t.TempDir(), "test.db")}
+}
+
+// openSQLiteDB opens db with registered models on a new sqlite database, which will be closed after test
+func openSQLiteDB(t *testing.T, name string, models ...builder.Model) *sqlx.DB {
+	dbTest := sqlx.NewDatabase(name)
+	for _, m := range models {
+		dbTest.Register(m)
+	}
+
+	db := dbTest.OpenDB(newSQLiteConnector(t))
+	closeAfterTest(t, db)
+
+	err := migration.Migrate(db, nil)
+	NewWithT(t).Expect(err).To(BeNil())
+
+	return db
+}
+
+func closeAfterTest(t *testing.T, db *sqlx.DB) {
+	t.Cleanup(func() {
+		_ = db.SqlExecutor.(*sql.DB).Close()
+	})
+}
+
+func insertMembers(t *testing.T, db sqlx.DBExecutor, names ...string) {
+	for _, name := range names {
+		_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: name}, nil))
+		NewWithT(t).Expect(err).To(BeNil())
+	}
+}
+
+func queryMemberNames(t *testing.T, db sqlx.DBExecutor, additions ...builder.Addition) []string {
+	list := make([]Member, 0)
+	err := db.QueryExprAndScan(builder.Select(nil).From(db.T(&Member{}), additions...), &list)
+	NewWithT(t).Expect(err).To(BeNil())
+
+	names := make([]string, len(list))
+	for i := range list {
+		names[i] = list[i].Name
+	}
+	return names
+}
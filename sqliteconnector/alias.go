@@ -0,0 +1,5 @@
+package sqliteconnector
+
+import "github.com/kunlun-qilian/sqlx/v3/connectors/sqlite"
+
+type SQLiteConnector = sqlite.SQLiteConnector
@@ -0,0 +1,89 @@
+package sqlx_test
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/kunlun-qilian/sqlx/v3/builder"
+	"github.com/kunlun-qilian/sqlx/v3/migration"
+	"github.com/kunlun-qilian/sqlx/v3/sqliteconnector"
+	. "github.com/onsi/gomega"
+)
+
+func TestStmtCache(t *testing.T) {
+	connector := newSQLiteConnector(t)
+	connector.StmtCacheSize = 2
+
+	dbTest := sqlx.NewDatabase("test_for_stmt_cache")
+	dbTest.Register(&Member{})
+
+	db := dbTest.OpenDB(connector)
+	closeAfterTest(t, db)
+	db.SetMaxOpenConns(1)
+
+	err := migration.Migrate(db, nil)
+	NewWithT(t).Expect(err).To(BeNil())
+
+	stats := db.Dialect().(*sqliteconnector.SQLiteConnector).StmtCacheStats()
+	hits, misses := stats.Hits(), stats.Misses()
+
+	t.Run("cache dml", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "a" + strconv.Itoa(i), Age: 18}, nil))
+			NewWithT(t).Expect(err).To(BeNil())
+		}
+
+		NewWithT(t).Expect(queryMemberNames(t, db)).To(HaveLen(3))
+
+		NewWithT(t).Expect(stats.Hits() - hits).To(Equal(uint64(2)))
+		NewWithT(t).Expect(stats.Misses() - misses).To(Equal(uint64(2)))
+	})
+
+	t.Run("skip ddl and savepoint", func(t *testing.T) {
+		hits, misses := stats.Hits(), stats.Misses()
+
+		_, err := db.ExecExpr(builder.Expr("CREATE TABLE t_tmp (f_id INTEGER)"))
+		NewWithT(t).Expect(err).To(BeNil())
+
+		_, err = db.ExecExpr(builder.Expr("SAVEPOINT sp"))
+		NewWithT(t).Expect(err).To(BeNil())
+		_, err = db.ExecExpr(builder.Expr("RELEASE SAVEPOINT sp"))
+		NewWithT(t).Expect(err).To(BeNil())
+
+		NewWithT(t).Expect(stats.Hits() - hits).To(Equal(uint64(0)))
+		NewWithT(t).Expect(stats.Misses() - misses).To(Equal(uint64(0)))
+	})
+
+	t.Run("evict when failed", func(t *testing.T) {
+		insert := sqlx.InsertToDB(db, &Member{Name: "b", Age: 18}, nil)
+
+		_, err := db.ExecExpr(insert)
+		NewWithT(t).Expect(err).To(BeNil())
+
+		hits, misses := stats.Hits(), stats.Misses()
+
+		// conflict of unique index
+		_, err = db.ExecExpr(insert)
+		NewWithT(t).Expect(err).NotTo(BeNil())
+
+		// prepared again after evicted
+		_, err = db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "c", Age: 18}, nil))
+		NewWithT(t).Expect(err).To(BeNil())
+
+		NewWithT(t).Expect(stats.Hits() - hits).To(Equal(uint64(1)))
+		NewWithT(t).Expect(stats.Misses() - misses).To(Equal(uint64(1)))
+	})
+
+	t.Run("prepare", func(t *testing.T) {
+		stmt, err := db.SqlExecutor.(*sql.DB).Prepare("SELECT count(1) FROM t_member WHERE f_age = ?")
+		NewWithT(t).Expect(err).To(BeNil())
+		defer stmt.Close()
+
+		count := 0
+		err = stmt.QueryRow(18).Scan(&count)
+		NewWithT(t).Expect(err).To(BeNil())
+		NewWithT(t).Expect(count).To(Equal(5))
+	})
+}
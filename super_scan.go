@@ -9,10 +9,25 @@ import (
 
 type ScanIterator = scanner.ScanIterator
 
+type Cursor = scanner.Cursor
+
+// Row values of columns keyed by column name with typed getters, could be used as scan target like *[]Row
+type Row = scanner.Row
+
+// StopIteration could be returned by the func of QueryExprEach to stop iteration without error
+var StopIteration = scanner.StopIteration
+
 func Scan(rows *sql.Rows, v interface{}) error {
-	if err := scanner.Scan(context.Background(), rows, v); err != nil {
+	return ScanContext(context.Background(), rows, v)
+}
+
+// ScanContext scan rows to v with ctx,
+// scanning will be stopped with ctx.Err() when ctx canceled,
+// and scan options could be set by ctx, like scanner.ContextWithScanStrict and scanner.ContextWithColumnNameMapper.
+func ScanContext(ctx context.Context, rows *sql.Rows, v interface{}) error {
+	if err := scanner.Scan(ctx, rows, v); err != nil {
 		if err == scanner.RecordNotFound {
-			return NewSqlError(sqlErrTypeNotFound, "record is not found")
+			return NewSqlError(SqlErrTypeNotFound, "record is not found")
 		}
 		return err
 	}
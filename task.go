@@ -2,7 +2,9 @@ package sqlx
 
 import (
 	"fmt"
+	"math/rand"
 	"runtime/debug"
+	"time"
 
 	"github.com/go-courier/logr"
 	"github.com/pkg/errors"
@@ -26,8 +28,10 @@ func NewTasks(db DBExecutor) *Tasks {
 }
 
 type Tasks struct {
-	db    DBExecutor
-	tasks []Task
+	db          DBExecutor
+	tasks       []Task
+	savepoint   bool
+	retryPolicy *RetryPolicy
 }
 
 func (tasks Tasks) With(task ...Task) *Tasks {
@@ -35,11 +39,87 @@ func (tasks Tasks) With(task ...Task) *Tasks {
 	return &tasks
 }
 
+// InSavepoint make tasks run in a savepoint when db is already in transaction,
+// failure of tasks will only rollback to the savepoint, outer transaction could continue.
+func (tasks Tasks) InSavepoint() *Tasks {
+	tasks.savepoint = true
+	return &tasks
+}
+
+// WithRetry replay the whole transaction when the error is retryable by dialect,
+// only works for the outermost transaction.
+func (tasks Tasks) WithRetry(retryPolicy RetryPolicy) *Tasks {
+	tasks.retryPolicy = &retryPolicy
+	return &tasks
+}
+
+// RetryableErrorDialect tells which errors (serialization failure, deadlock, etc.) could be fixed by replaying transaction
+type RetryableErrorDialect interface {
+	IsErrorRetryable(err error) bool
+}
+
+type RetryPolicy struct {
+	// MaxAttempts max times to run tasks, includes the first run
+	MaxAttempts int
+	// Backoff wait duration before the second attempt, doubled for each next attempt
+	Backoff time.Duration
+	// MaxBackoff max wait duration, no limit when zero
+	MaxBackoff time.Duration
+	// Jitter random factor in [0, 1] to spread the wait duration
+	Jitter float64
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff << uint(attempt-1)
+	if d < 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d = d + time.Duration(float64(d)*p.Jitter*(rand.Float64()*2-1))
+	}
+	return d
+}
+
+func (tasks *Tasks) shouldRetry(attempt int, err error) bool {
+	if tasks.retryPolicy == nil || attempt >= tasks.retryPolicy.MaxAttempts {
+		return false
+	}
+	if maybeTx, ok := tasks.db.(MaybeTxExecutor); !ok || maybeTx.IsTx() {
+		// transaction in outer could not be replayed here
+		return false
+	}
+	if retryableErrorDialect, ok := tasks.db.Dialect().(RetryableErrorDialect); ok {
+		return retryableErrorDialect.IsErrorRetryable(err)
+	}
+	return false
+}
+
 func (tasks *Tasks) Do() (err error) {
 	if len(tasks.tasks) == 0 {
 		return nil
 	}
 
+	for attempt := 1; ; attempt++ {
+		err = tasks.do()
+		if err == nil || !tasks.shouldRetry(attempt, err) {
+			return err
+		}
+
+		ctx := tasks.db.Context()
+		logr.FromContext(ctx).Warn(errors.Wrapf(err, "RETRY TRANSACTION, attempt %d", attempt+1))
+
+		timer := time.NewTimer(tasks.retryPolicy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (tasks *Tasks) do() (err error) {
+
 	db := tasks.db
 
 	log := logr.FromContext(db.Context())
@@ -47,7 +127,7 @@ func (tasks *Tasks) Do() (err error) {
 	if maybeTx, ok := db.(MaybeTxExecutor); ok {
 		inTxScope := false
 
-		if !maybeTx.IsTx() {
+		if !maybeTx.IsTx() || tasks.savepoint {
 			db, err = maybeTx.Begin()
 			if err != nil {
 				return err
@@ -4,11 +4,13 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kunlun-qilian/sqlx/v3"
 	"github.com/kunlun-qilian/sqlx/v3/builder"
 	"github.com/kunlun-qilian/sqlx/v3/migration"
+	"github.com/mattn/go-sqlite3"
 	"github.com/onsi/gomega"
 )
 
@@ -125,6 +127,57 @@ func TestWithTasks(t *testing.T) {
 				})
 			}
 
+			t.Run("rollback to savepoint", func(t *testing.T) {
+				taskList := sqlx.NewTasks(db)
+
+				user := User{
+					Name:   uuid.New().String(),
+					Gender: GenderMale,
+				}
+
+				taskList = taskList.With(func(db sqlx.DBExecutor) error {
+					_, err := db.ExecExpr(sqlx.InsertToDB(db, &user, nil))
+					return err
+				})
+
+				userInSavepoint := User{
+					Name:   uuid.New().String(),
+					Gender: GenderMale,
+				}
+
+				taskList = taskList.With(func(db sqlx.DBExecutor) error {
+					subTaskList := sqlx.NewTasks(db).InSavepoint()
+
+					subTaskList = subTaskList.With(func(db sqlx.DBExecutor) error {
+						_, err := db.ExecExpr(sqlx.InsertToDB(db, &userInSavepoint, nil))
+						return err
+					})
+
+					subTaskList = subTaskList.With(func(db sqlx.DBExecutor) error {
+						_, err := db.ExecExpr(sqlx.InsertToDB(db, &user, nil))
+						return err
+					})
+
+					err := subTaskList.Do()
+					gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+					return nil
+				})
+
+				err := taskList.Do()
+				gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+				count := 0
+				err = db.QueryExprAndScan(
+					builder.Select(builder.Count()).From(
+						db.T(&user),
+						builder.Where(db.T(&user).F("Name").In(user.Name, userInSavepoint.Name)),
+					),
+					&count,
+				)
+				gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+				gomega.NewWithT(t).Expect(count).To(gomega.Equal(1))
+			})
+
 			t.Run("transaction chain", func(t *testing.T) {
 				taskList := sqlx.NewTasks(db)
 
@@ -173,3 +226,83 @@ func TestWithTasks(t *testing.T) {
 		})
 	}
 }
+
+func TestSavepoint(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_savepoint", &Member{})
+
+	err := sqlx.NewTasks(db).
+		With(func(db sqlx.DBExecutor) error {
+			_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "a"}, nil))
+			return err
+		}).
+		With(func(db sqlx.DBExecutor) error {
+			err := sqlx.NewTasks(db).InSavepoint().
+				With(func(db sqlx.DBExecutor) error {
+					_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "b"}, nil))
+					return err
+				}).
+				With(func(db sqlx.DBExecutor) error {
+					_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "a"}, nil))
+					return err
+				}).
+				Do()
+			gomega.NewWithT(t).Expect(sqlx.DBErr(err).IsConflict()).To(gomega.BeTrue())
+			return nil
+		}).
+		With(func(db sqlx.DBExecutor) error {
+			_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: "c"}, nil))
+			return err
+		}).
+		Do()
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+
+	list := make([]Member, 0)
+	err = db.QueryExprAndScan(builder.Select(nil).From(db.T(&Member{})), &list)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(2))
+	gomega.NewWithT(t).Expect(list[0].Name).To(gomega.Equal("a"))
+	gomega.NewWithT(t).Expect(list[1].Name).To(gomega.Equal("c"))
+}
+
+func TestRetry(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_retry", &Member{})
+
+	attempts := 0
+
+	err := sqlx.NewTasks(db).
+		WithRetry(sqlx.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, Jitter: 0.5}).
+		With(func(db sqlx.DBExecutor) error {
+			attempts++
+			_, err := db.ExecExpr(sqlx.InsertToDB(db, &Member{Name: fmt.Sprintf("%d", attempts)}, nil))
+			if err != nil {
+				return err
+			}
+			if attempts < 3 {
+				return sqlite3.Error{Code: sqlite3.ErrBusy}
+			}
+			return nil
+		}).
+		Do()
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(attempts).To(gomega.Equal(3))
+
+	list := make([]Member, 0)
+	err = db.QueryExprAndScan(builder.Select(nil).From(db.T(&Member{})), &list)
+	gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+	gomega.NewWithT(t).Expect(list).To(gomega.HaveLen(1))
+	gomega.NewWithT(t).Expect(list[0].Name).To(gomega.Equal("3"))
+
+	t.Run("not retryable", func(t *testing.T) {
+		attempts := 0
+
+		err := sqlx.NewTasks(db).
+			WithRetry(sqlx.RetryPolicy{MaxAttempts: 3}).
+			With(func(db sqlx.DBExecutor) error {
+				attempts++
+				return fmt.Errorf("failed")
+			}).
+			Do()
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(attempts).To(gomega.Equal(1))
+	})
+}
@@ -0,0 +1,114 @@
+package sqlx
+
+import (
+	"context"
+	"sync"
+)
+
+type TxHookRegister interface {
+	RegisterAfterCommit(fn func(ctx context.Context))
+	RegisterAfterRollback(fn func(ctx context.Context))
+}
+
+// AfterCommit register fn to run after the outermost transaction committed.
+// fn will be dropped when transaction rollback, and run immediately when db is not in transaction.
+func AfterCommit(db DBExecutor, fn func(ctx context.Context)) {
+	if r, ok := db.(TxHookRegister); ok && isTx(db) {
+		r.RegisterAfterCommit(fn)
+		return
+	}
+	fn(db.Context())
+}
+
+// AfterRollback register fn to run after transaction (or savepoint) rollback.
+// fn will be dropped when db is not in transaction.
+func AfterRollback(db DBExecutor, fn func(ctx context.Context)) {
+	if r, ok := db.(TxHookRegister); ok && isTx(db) {
+		r.RegisterAfterRollback(fn)
+	}
+}
+
+func isTx(db DBExecutor) bool {
+	maybeTx, ok := db.(MaybeTxExecutor)
+	return ok && maybeTx.IsTx()
+}
+
+func (d *DB) RegisterAfterCommit(fn func(ctx context.Context)) {
+	if d.hooks != nil {
+		d.hooks.add(fn, nil)
+	}
+}
+
+func (d *DB) RegisterAfterRollback(fn func(ctx context.Context)) {
+	if d.hooks != nil {
+		d.hooks.add(nil, fn)
+	}
+}
+
+type txHooks struct {
+	parent        *txHooks
+	mu            sync.Mutex
+	afterCommit   []func(ctx context.Context)
+	afterRollback []func(ctx context.Context)
+}
+
+func (h *txHooks) add(afterCommit func(ctx context.Context), afterRollback func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if afterCommit != nil {
+		h.afterCommit = append(h.afterCommit, afterCommit)
+	}
+	if afterRollback != nil {
+		h.afterRollback = append(h.afterRollback, afterRollback)
+	}
+}
+
+func (h *txHooks) flush() (afterCommit []func(ctx context.Context), afterRollback []func(ctx context.Context)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	afterCommit, afterRollback = h.afterCommit, h.afterRollback
+	h.afterCommit, h.afterRollback = nil, nil
+	return
+}
+
+// bubble hooks up to parent when savepoint released
+func (h *txHooks) bubble() {
+	if h == nil || h.parent == nil {
+		return
+	}
+
+	afterCommit, afterRollback := h.flush()
+
+	for i := range afterCommit {
+		h.parent.add(afterCommit[i], nil)
+	}
+	for i := range afterRollback {
+		h.parent.add(nil, afterRollback[i])
+	}
+}
+
+func (h *txHooks) committed(ctx context.Context) {
+	if h == nil {
+		return
+	}
+
+	afterCommit, _ := h.flush()
+
+	for _, fn := range afterCommit {
+		fn(ctx)
+	}
+}
+
+func (h *txHooks) rolledBack(ctx context.Context) {
+	if h == nil {
+		return
+	}
+
+	_, afterRollback := h.flush()
+
+	for _, fn := range afterRollback {
+		fn(ctx)
+	}
+}
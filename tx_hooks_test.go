@@ -0,0 +1,97 @@
+package sqlx_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kunlun-qilian/sqlx/v3"
+	"github.com/onsi/gomega"
+)
+
+func TestTxHooks(t *testing.T) {
+	db := openSQLiteDB(t, "test_for_tx_hooks", &Member{})
+
+	t.Run("without tx", func(t *testing.T) {
+		called := false
+		sqlx.AfterCommit(db, func(ctx context.Context) {
+			called = true
+		})
+		gomega.NewWithT(t).Expect(called).To(gomega.BeTrue())
+	})
+
+	t.Run("in tasks", func(t *testing.T) {
+		events := make([]string, 0)
+
+		err := sqlx.NewTasks(db).
+			With(func(db sqlx.DBExecutor) error {
+				sqlx.AfterCommit(db, func(ctx context.Context) {
+					events = append(events, "commit")
+				})
+				gomega.NewWithT(t).Expect(events).To(gomega.HaveLen(0))
+				return nil
+			}).
+			With(func(db sqlx.DBExecutor) error {
+				return sqlx.NewTasks(db).
+					With(func(db sqlx.DBExecutor) error {
+						sqlx.AfterCommit(db, func(ctx context.Context) {
+							events = append(events, "nested commit")
+						})
+						return nil
+					}).
+					Do()
+			}).
+			With(func(db sqlx.DBExecutor) error {
+				_ = sqlx.NewTasks(db).InSavepoint().
+					With(func(db sqlx.DBExecutor) error {
+						sqlx.AfterCommit(db, func(ctx context.Context) {
+							events = append(events, "savepoint commit")
+						})
+						sqlx.AfterRollback(db, func(ctx context.Context) {
+							events = append(events, "savepoint rollback")
+						})
+						return fmt.Errorf("failed")
+					}).
+					Do()
+				return nil
+			}).
+			With(func(db sqlx.DBExecutor) error {
+				return sqlx.NewTasks(db).InSavepoint().
+					With(func(db sqlx.DBExecutor) error {
+						sqlx.AfterCommit(db, func(ctx context.Context) {
+							events = append(events, "released savepoint commit")
+						})
+						return nil
+					}).
+					Do()
+			}).
+			Do()
+
+		gomega.NewWithT(t).Expect(err).To(gomega.BeNil())
+		gomega.NewWithT(t).Expect(events).To(gomega.Equal([]string{
+			"savepoint rollback",
+			"commit",
+			"nested commit",
+			"released savepoint commit",
+		}))
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		events := make([]string, 0)
+
+		err := sqlx.NewTasks(db).
+			With(func(db sqlx.DBExecutor) error {
+				sqlx.AfterCommit(db, func(ctx context.Context) {
+					events = append(events, "commit")
+				})
+				sqlx.AfterRollback(db, func(ctx context.Context) {
+					events = append(events, "rollback")
+				})
+				return fmt.Errorf("failed")
+			}).
+			Do()
+
+		gomega.NewWithT(t).Expect(err).NotTo(gomega.BeNil())
+		gomega.NewWithT(t).Expect(events).To(gomega.Equal([]string{"rollback"}))
+	})
+}
@@ -11,8 +11,12 @@ func UnwrapAll(err error) error {
 	return err
 }
 
+// UnwrapOnce returns the cause of err,
+// SqlError is the end of chain, its driver error should be found by errors.As
 func UnwrapOnce(err error) (cause error) {
 	switch e := err.(type) {
+	case *SqlError:
+		return nil
 	case interface{ Cause() error }:
 		return e.Cause()
 	case interface{ Unwrap() error }: